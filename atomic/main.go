@@ -24,11 +24,17 @@ func main() {
 	section("Patrón: contador lock-free vs Mutex")
 	demoLockFreeCounter()
 
+	section("Patrón: false sharing — PaddedCounter y ShardedCounter")
+	demoFalseSharing()
+
 	section("Patrón: flag de cierre (shutdown flag)")
 	demoShutdownFlag()
 
 	section("Patrón: referencia compartida (copy-on-write)")
 	demoCopyOnWrite()
+
+	section("Patrón: Versioned[T] — optimistic locking con versión")
+	demoVersioned()
 }
 
 func section(title string) {
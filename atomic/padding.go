@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cacheLineSize is the unit the CPU caches move memory in: 64 bytes on
+// amd64 and most arm64 cores (Apple M-series use 128, where padding to 64
+// still halves the sharing).
+const cacheLineSize = 64
+
+// PaddedCounter is an atomic.Int64 alone on its cache line.
+//
+// Two atomics that are independent for the program but share a cache line
+// are not independent for the hardware: every Add takes the whole line in
+// exclusive mode, so cores writing "their own" counter keep stealing the
+// line from each other (false sharing). The padding before and after makes
+// sure no neighbour — the previous element of a slice, the next field of a
+// struct — lands on the same line.
+type PaddedCounter struct {
+	_ [cacheLineSize]byte
+	n atomic.Int64
+	_ [cacheLineSize - 8]byte
+}
+
+// Add adds delta to the counter and returns the new value.
+func (c *PaddedCounter) Add(delta int64) int64 { return c.n.Add(delta) }
+
+// Load returns the current value.
+func (c *PaddedCounter) Load() int64 { return c.n.Load() }
+
+// ShardedCounter is a counter for write-heavy, read-rarely workloads such as
+// request counts. Writers add to one of several padded shards, so concurrent
+// Adds rarely touch the same cache line; Sum adds the shards up.
+//
+// The trade-off is on the read side: Sum is O(shards) and, with writers
+// running, a snapshot that may already be stale — fine for metrics, wrong
+// for a value that drives a decision (use a single atomic or a Mutex).
+type ShardedCounter struct {
+	shards []PaddedCounter
+	mask   uint32
+}
+
+// NewShardedCounter returns a counter with one shard per P (GOMAXPROCS),
+// rounded up to a power of two.
+func NewShardedCounter() *ShardedCounter {
+	n := 1
+	for n < runtime.GOMAXPROCS(0) {
+		n <<= 1
+	}
+	return &ShardedCounter{shards: make([]PaddedCounter, n), mask: uint32(n - 1)}
+}
+
+// Add adds delta to a shard. Go does not expose the current CPU, so the
+// shard is picked at random; math/rand/v2's generator is per-P, so the pick
+// itself does not contend.
+func (c *ShardedCounter) Add(delta int64) {
+	c.shards[rand.Uint32()&c.mask].Add(delta)
+}
+
+// Sum returns the total of all shards.
+func (c *ShardedCounter) Sum() int64 {
+	var total int64
+	for i := range c.shards {
+		total += c.shards[i].Load()
+	}
+	return total
+}
+
+// demoFalseSharing times goroutines that each increment their own counter,
+// once with the counters packed next to each other and once padded. No
+// counter is shared, so both are correct; only the padded one scales.
+//
+// Timings are noisy; for numbers, run the benchmark:
+//
+//	go test -bench=FalseSharing
+func demoFalseSharing() {
+	workers := runtime.GOMAXPROCS(0)
+	const increments = 1_000_000
+
+	run := func(inc func(i int)) time.Duration {
+		var wg sync.WaitGroup
+		start := time.Now()
+		wg.Add(workers)
+		for i := range workers {
+			go func() {
+				defer wg.Done()
+				for range increments {
+					inc(i)
+				}
+			}()
+		}
+		wg.Wait()
+		return time.Since(start)
+	}
+
+	naive := make([]atomic.Int64, workers) // 8 counters per cache line
+	padded := make([]PaddedCounter, workers)
+	naiveDur := run(func(i int) { naive[i].Add(1) })
+	paddedDur := run(func(i int) { padded[i].Add(1) })
+
+	fmt.Printf("  %d goroutines × %d increments on their own counter\n", workers, increments)
+	fmt.Printf("  []atomic.Int64 (adjacent): %v\n", naiveDur.Round(time.Millisecond))
+	fmt.Printf("  []PaddedCounter:           %v\n", paddedDur.Round(time.Millisecond))
+
+	sharded := NewShardedCounter()
+	run(func(int) { sharded.Add(1) })
+	fmt.Printf("  ShardedCounter (%d shards) Sum = %d; want %d\n",
+		len(sharded.shards), sharded.Sum(), workers*increments)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+// TestShardedCounterSum runs concurrent Adds and checks that Sum equals the
+// total added, none lost across shards.
+func TestShardedCounterSum(t *testing.T) {
+	const goroutines, perGoroutine = 32, 10_000
+
+	c := NewShardedCounter()
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for range goroutines {
+		go func() {
+			defer wg.Done()
+			for range perGoroutine {
+				c.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.Sum(); got != goroutines*perGoroutine {
+		t.Errorf("Sum() = %d; want %d", got, goroutines*perGoroutine)
+	}
+}
+
+// TestPaddedCounterLayout checks that adjacent PaddedCounters in a slice
+// keep their values on different cache lines.
+func TestPaddedCounterLayout(t *testing.T) {
+	cs := make([]PaddedCounter, 2)
+	a := uintptr(unsafe.Pointer(&cs[0].n))
+	b := uintptr(unsafe.Pointer(&cs[1].n))
+	if b-a < cacheLineSize {
+		t.Errorf("adjacent counters %d bytes apart; want >= %d", b-a, cacheLineSize)
+	}
+}
+
+// BenchmarkFalseSharing has every goroutine increment its own counter, so
+// nothing is logically shared. In "naive" the counters are adjacent in a
+// []atomic.Int64 and eight share each cache line; in "padded" each sits on
+// its own line. The gap between the two is the cost of false sharing, and
+// it grows with -cpu:
+//
+//	go test -bench=FalseSharing -cpu=1,4,8
+func BenchmarkFalseSharing(b *testing.B) {
+	slots := runtime.GOMAXPROCS(0)
+
+	bench := func(b *testing.B, inc func(i int)) {
+		var next atomic.Int32
+		b.RunParallel(func(pb *testing.PB) {
+			i := int(next.Add(1)-1) % slots // this goroutine's own counter
+			for pb.Next() {
+				inc(i)
+			}
+		})
+	}
+
+	b.Run("naive", func(b *testing.B) {
+		cs := make([]atomic.Int64, slots)
+		bench(b, func(i int) { cs[i].Add(1) })
+	})
+	b.Run("padded", func(b *testing.B) {
+		cs := make([]PaddedCounter, slots)
+		bench(b, func(i int) { cs[i].Add(1) })
+	})
+}
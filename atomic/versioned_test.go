@@ -0,0 +1,60 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestVersionedZeroValue checks the zero value and a basic CAS sequence.
+func TestVersionedZeroValue(t *testing.T) {
+	var v Versioned[string]
+
+	if val, ver := v.Load(); val != "" || ver != 0 {
+		t.Fatalf("zero Load() = (%q, %d); want (\"\", 0)", val, ver)
+	}
+	if !v.CompareAndSwap(0, "first") {
+		t.Fatal("CAS(0) on zero value failed")
+	}
+	if v.CompareAndSwap(0, "stale") {
+		t.Error("CAS with stale version succeeded")
+	}
+	if val, ver := v.Load(); val != "first" || ver != 1 {
+		t.Errorf("Load() = (%q, %d); want (\"first\", 1)", val, ver)
+	}
+}
+
+// TestVersionedNoLostUpdates runs many concurrent read-modify-write loops and
+// checks that every increment survived and that the version equals the number
+// of successful swaps.
+func TestVersionedNoLostUpdates(t *testing.T) {
+	const goroutines = 16
+	const increments = 500
+
+	var counter Versioned[int]
+	var swaps atomic.Int64
+	var wg sync.WaitGroup
+
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increments; {
+				n, ver := counter.Load()
+				if counter.CompareAndSwap(ver, n+1) {
+					swaps.Add(1)
+					i++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	val, ver := counter.Load()
+	if want := goroutines * increments; val != want {
+		t.Errorf("value = %d; want %d (lost updates)", val, want)
+	}
+	if ver != uint64(swaps.Load()) {
+		t.Errorf("version = %d; want %d (successful swaps)", ver, swaps.Load())
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -33,45 +34,20 @@ func demoDone() {
 	wg.Wait()
 }
 
-// demoOrDone shows the or-done wrapper: read from a value channel but
-// stop immediately if a done signal arrives. Avoids goroutine leaks when
-// the producer outlives the consumer.
-func demoOrDone() {
-	done := make(chan struct{})
-	values := make(chan int)
-
-	// Producer sends indefinitely.
-	go func() {
-		for i := 0; ; i++ {
-			select {
-			case <-done:
-				return
-			case values <- i:
-			}
-		}
-	}()
-
-	// Consumer reads a few values, then cancels.
-	for v := range orDone(done, values) {
-		fmt.Printf("  %d ", v)
-		if v >= 4 {
-			close(done) // signal producer to stop
-			break
-		}
-	}
-	fmt.Println()
-}
-
-// orDone wraps a value channel so that ranging over the returned channel
-// is always safe: it exits cleanly when done is closed, even if the
-// underlying channel is never closed by its producer.
-func orDone(done <-chan struct{}, in <-chan int) <-chan int {
-	out := make(chan int)
+// Cancellable is the or-done wrapper: it forwards values from in until ctx
+// is done or in is closed, then closes the returned channel, so ranging over
+// it is safe even when the producer never closes in.
+//
+// The forwarding goroutine always exits — including when it is blocked
+// sending to a consumer that stopped reading — so the only requirement on
+// the consumer is to cancel ctx when it leaves early.
+func Cancellable[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
 	go func() {
 		defer close(out)
 		for {
 			select {
-			case <-done:
+			case <-ctx.Done():
 				return
 			case v, ok := <-in:
 				if !ok {
@@ -79,7 +55,7 @@ func orDone(done <-chan struct{}, in <-chan int) <-chan int {
 				}
 				select {
 				case out <- v:
-				case <-done:
+				case <-ctx.Done():
 					return
 				}
 			}
@@ -87,3 +63,34 @@ func orDone(done <-chan struct{}, in <-chan int) <-chan int {
 	}()
 	return out
 }
+
+// demoCancellable stops reading from a producer that never closes its
+// channel by cancelling a context instead of closing a done channel. The
+// producer watches the same ctx, so it stops too instead of staying blocked
+// on a send nobody will receive.
+func demoCancellable() {
+	ctx, cancel := context.WithTimeout(context.Background(), 35*time.Millisecond)
+	defer cancel()
+
+	ticks := make(chan string)
+	stopped := make(chan struct{})
+	// Stands in for a stream we don't own (a library callback, a socket
+	// reader) that never closes its channel.
+	go func() {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			select {
+			case ticks <- fmt.Sprintf("tick-%d", i):
+			case <-ctx.Done():
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	for v := range Cancellable(ctx, ticks) { // ends when the timeout fires
+		fmt.Printf("  %s\n", v)
+	}
+	<-stopped
+	fmt.Println("  output closed, producer stopped:", ctx.Err())
+}
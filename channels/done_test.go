@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestCancellablePassesValues checks that values flow through unchanged and
+// the output closes when the input does.
+func TestCancellablePassesValues(t *testing.T) {
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	close(in)
+
+	var got []int
+	for v := range Cancellable(context.Background(), in) {
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestCancellableClosesOnCancel checks that cancelling ctx closes the output
+// promptly even though the input is never closed.
+func TestCancellableClosesOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Cancellable(ctx, make(chan int)) // input never sends nor closes
+
+	cancel()
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("received a value; want the output closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("output not closed within 1s of cancel")
+	}
+}
+
+// TestCancellableNoLeak starts many wrappers, including ones blocked sending
+// to a consumer that stopped reading, cancels them, and checks that the
+// goroutine count returns to where it started.
+func TestCancellableNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 50; i++ {
+		in := make(chan int, 1)
+		in <- i // the forwarder takes it and blocks on the unread output
+		if i%2 == 0 {
+			close(in)
+		}
+		Cancellable(ctx, in)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("NumGoroutine = %d; want <= %d after cancel", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Generate is the generic, leak-free version of generate in pipeline.go.
+// generate blocks forever on `out <- n` if its consumer walks away; here every
+// send also watches ctx, so cancelling ctx is enough to stop the producer.
+//
+// fn produces values by calling emit. emit returns false once ctx is done —
+// the consumer is gone — and fn must then return. The output channel is
+// closed when fn returns.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel() // stops the generator when the consumer is done
+//	for v := range Take(ctx, Generate(ctx, naturals), 5) { ... }
+func Generate[T any](ctx context.Context, fn func(emit func(T) bool)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		fn(func(v T) bool {
+			select {
+			case out <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return out
+}
+
+// Take forwards at most n values from in, then closes its output. It stops
+// early if in is closed or ctx is done. Take does not drain in: cancel ctx
+// to release the upstream producer.
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for i := 0; i < n; i++ {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func demoGenerate() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stopped := make(chan struct{})
+	fib := Generate(ctx, func(emit func(int) bool) {
+		defer close(stopped)
+		a, b := 0, 1
+		for emit(a) { // infinite: runs until the consumer cancels
+			a, b = b, a+b
+		}
+	})
+
+	for v := range Take(ctx, fib, 10) {
+		fmt.Printf("%d ", v)
+	}
+	fmt.Println()
+
+	cancel() // consumer is done → the next emit returns false
+	<-stopped
+	fmt.Println("  generator goroutine exited after cancel (no leak)")
+}
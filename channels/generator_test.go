@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestGenerateTakeStopsProducer consumes an infinite generator through Take,
+// cancels, and checks that the generator function returns and its channel is
+// closed — i.e. the producer goroutine does not leak.
+func TestGenerateTakeStopsProducer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	exited := make(chan struct{})
+	naturals := Generate(ctx, func(emit func(int) bool) {
+		defer close(exited)
+		for i := 0; emit(i); i++ {
+		}
+	})
+
+	var got []int
+	for v := range Take(ctx, naturals, 5) {
+		got = append(got, v)
+	}
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	cancel()
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("generator still running after cancel: goroutine leak")
+	}
+
+	// The output channel must be closed once fn returns. Drain a possibly
+	// in-flight value first.
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-naturals:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("generator channel not closed after fn returned")
+		}
+	}
+}
+
+// TestGenerateFinite checks that a generator which returns on its own closes
+// the channel after emitting every value.
+func TestGenerateFinite(t *testing.T) {
+	words := Generate(context.Background(), func(emit func(string) bool) {
+		for _, w := range []string{"a", "b", "c"} {
+			if !emit(w) {
+				return
+			}
+		}
+	})
+
+	var got []string
+	for w := range words {
+		got = append(got, w)
+	}
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
@@ -27,26 +27,56 @@ func main() {
 	section("Select: timeout")
 	demoSelectTimeout()
 
+	section("Select: dynamic set of named channels (Orchestrate)")
+	demoOrchestrate()
+
+	section("Select fairness: random vs round-robin (RoundRobin)")
+	demoRoundRobin()
+
 	section("Pipeline")
 	demoPipeline()
 
+	section("Generic generator + Take (context-cancellable)")
+	demoGenerate()
+
+	section("Tumbling windows (WindowBy)")
+	demoWindowBy()
+
+	section("Pipeline with cancellation (done channel)")
+	demoPipelineCancel()
+
+	section("K-way merge of sorted channels (MergeSortedChans)")
+	demoMergeSorted()
+
+	section("Ordered concurrent map (MapChan)")
+	demoMapChan()
+
 	section("Fan-out")
 	demoFanOut()
 
 	section("Fan-in (merge)")
 	demoFanIn()
 
+	section("Producer-consumer with backpressure (RunProducerConsumer)")
+	demoProducerConsumer()
+
 	section("Worker pool")
 	demoWorkerPool()
 
+	section("Pub/sub with replay (ReplayHub)")
+	demoReplayHub()
+
 	section("Semaphore")
 	demoSemaphore()
 
 	section("Done channel")
 	demoDone()
 
-	section("Or-done channel")
-	demoOrDone()
+	section("Or-done channel (Cancellable[T])")
+	demoCancellable()
+
+	section("SafeChan[T] — Send/Close without panics")
+	demoSafeChan()
 }
 
 func section(title string) {
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MapChan applies f to every value from in using workers goroutines and
+// emits the results on the first channel in input order, even though the
+// calls finish out of order. A failed call emits its error, wrapped with the
+// item's 0-based position, on the second channel instead of a value.
+//
+// Out-of-order results wait in a reorder buffer keyed by sequence number. At
+// most 2·workers items are in flight, so one slow item holds back a bounded
+// amount of work instead of letting the buffer grow without limit.
+//
+// Both channels close once in is closed and every item is emitted, or as soon
+// as ctx is done; all goroutines exit either way. The consumer must read both
+// channels (a select loop) or cancel ctx.
+func MapChan[T, U any](ctx context.Context, in <-chan T, workers int, f func(context.Context, T) (U, error)) (<-chan U, <-chan error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type job struct {
+		seq int
+		v   T
+	}
+	type result struct {
+		seq int
+		v   U
+		err error
+	}
+
+	jobs := make(chan job)
+	results := make(chan result)
+	inFlight := make(chan struct{}, 2*workers) // one token per item not yet emitted
+	out := make(chan U)
+	errs := make(chan error)
+
+	// Dispatcher: numbers the items and hands them to the workers.
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			select {
+			case inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			var v T
+			select {
+			case x, ok := <-in:
+				if !ok {
+					return
+				}
+				v = x
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{seq, v}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				u, err := f(ctx, j.v)
+				select {
+				case results <- result{j.seq, u, err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Collector: buffers results until the next expected one arrives.
+	go func() {
+		defer close(out)
+		defer close(errs)
+
+		pending := make(map[int]result)
+		next := 0
+		for {
+			select {
+			case r, ok := <-results:
+				if !ok {
+					return
+				}
+				pending[r.seq] = r
+			case <-ctx.Done():
+				return
+			}
+
+			for r, ok := pending[next]; ok; r, ok = pending[next] {
+				delete(pending, next)
+				next++
+				if r.err != nil {
+					select {
+					case errs <- fmt.Errorf("item %d: %w", r.seq, r.err):
+					case <-ctx.Done():
+						return
+					}
+				} else {
+					select {
+					case out <- r.v:
+					case <-ctx.Done():
+						return
+					}
+				}
+				<-inFlight
+			}
+		}
+	}()
+
+	return out, errs
+}
+
+// demoMapChan squares numbers with 3 workers and random per-item latency;
+// the output still comes back in input order.
+func demoMapChan() {
+	slowSquare := func(ctx context.Context, n int) (int, error) {
+		time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+		if n == 5 {
+			return 0, fmt.Errorf("%d is unlucky", n)
+		}
+		return n * n, nil
+	}
+
+	out, errs := MapChan(context.Background(), generate(1, 2, 3, 4, 5, 6, 7, 8), 3, slowSquare)
+	for out != nil || errs != nil {
+		select {
+		case v, ok := <-out:
+			if !ok {
+				out = nil
+				continue
+			}
+			fmt.Printf("%d ", v)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			fmt.Printf("[%v] ", err)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+// drainMapChan reads out and errs until both close.
+func drainMapChan[U any](out <-chan U, errs <-chan error) ([]U, []error) {
+	var vals []U
+	var errList []error
+	for out != nil || errs != nil {
+		select {
+		case v, ok := <-out:
+			if !ok {
+				out = nil
+				continue
+			}
+			vals = append(vals, v)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			errList = append(errList, err)
+		}
+	}
+	return vals, errList
+}
+
+func intsChan(n int) <-chan int {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < n; i++ {
+			in <- i
+		}
+	}()
+	return in
+}
+
+// TestMapChanPreservesOrder gives each item a random duration, so the
+// workers finish out of order, and checks the output is still in input order.
+func TestMapChanPreservesOrder(t *testing.T) {
+	const n = 100
+	double := func(ctx context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(rand.Intn(3000)) * time.Microsecond)
+		return 2 * v, nil
+	}
+
+	got, errs := drainMapChan(MapChan(context.Background(), intsChan(n), 8, double))
+
+	want := make([]int, n)
+	for i := range want {
+		want[i] = 2 * i
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errs = %v; want none", errs)
+	}
+}
+
+// TestMapChanErrors checks that a failing item goes to the error channel,
+// wrapping f's error, while the other items still come out in order.
+func TestMapChanErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	evenOnly := func(ctx context.Context, v int) (int, error) {
+		if v%2 == 1 {
+			return 0, errOdd
+		}
+		return v, nil
+	}
+
+	got, errs := drainMapChan(MapChan(context.Background(), intsChan(6), 3, evenOnly))
+
+	if want := []int{0, 2, 4}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("got %d errors; want 3", len(errs))
+	}
+	for _, err := range errs {
+		if !errors.Is(err, errOdd) {
+			t.Errorf("err = %v; want it to wrap errOdd", err)
+		}
+	}
+}
+
+// TestMapChanCancelNoLeak cancels a MapChan whose consumer never reads and
+// whose input never closes, and checks that both outputs close and every
+// goroutine exits.
+func TestMapChanCancelNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case in <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	identity := func(ctx context.Context, v int) (int, error) { return v, nil }
+	out, errs := MapChan(ctx, in, 4, identity)
+
+	time.Sleep(10 * time.Millisecond) // let the pipeline fill up and block
+	cancel()
+	drainMapChan(out, errs) // returns only once both channels are closed
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("NumGoroutine = %d; want <= %d after cancel", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+)
+
+// MergeSortedChans merges channels that are each sorted by cmp into one
+// sorted stream — the k-way merge behind merging log files by timestamp.
+//
+// It keeps the current head of every open input in a min-heap: it pops the
+// smallest, emits it and refills from the input it came from. To know which
+// head is smallest it must have one from every open input, so a slow input
+// holds the whole merge back; that is inherent to sorted merging. Ties go to
+// the earlier input in chans, so the merge is stable.
+//
+// The output closes once every input is closed and drained, or when ctx is
+// done.
+func MergeSortedChans[T any](ctx context.Context, cmp func(a, b T) int, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		h := &headHeap[T]{cmp: cmp}
+		// pull reads the next value of input i into the heap; it returns
+		// false if ctx ended first.
+		pull := func(i int) bool {
+			select {
+			case v, ok := <-chans[i]:
+				if ok {
+					heap.Push(h, head[T]{v: v, src: i})
+				}
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for i := range chans {
+			if !pull(i) {
+				return
+			}
+		}
+		for h.Len() > 0 {
+			next := heap.Pop(h).(head[T])
+			select {
+			case out <- next.v:
+			case <-ctx.Done():
+				return
+			}
+			if !pull(next.src) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// head is the current front value of input src.
+type head[T any] struct {
+	v   T
+	src int
+}
+
+// headHeap is a container/heap min-heap of heads ordered by cmp, then src.
+type headHeap[T any] struct {
+	items []head[T]
+	cmp   func(a, b T) int
+}
+
+func (h *headHeap[T]) Len() int { return len(h.items) }
+func (h *headHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.items[i].v, h.items[j].v); c != 0 {
+		return c < 0
+	}
+	return h.items[i].src < h.items[j].src
+}
+func (h *headHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *headHeap[T]) Push(x any)    { h.items = append(h.items, x.(head[T])) }
+func (h *headHeap[T]) Pop() any {
+	old := h.items
+	x := old[len(old)-1]
+	h.items = old[:len(old)-1]
+	return x
+}
+
+// demoMergeSorted merges three log files, each sorted by timestamp.
+func demoMergeSorted() {
+	type entry struct {
+		ts  int
+		msg string
+	}
+	file := func(entries ...entry) <-chan entry {
+		ch := make(chan entry)
+		go func() {
+			defer close(ch)
+			for _, e := range entries {
+				ch <- e
+			}
+		}()
+		return ch
+	}
+
+	api := file(entry{1, "api: request"}, entry{4, "api: response"}, entry{9, "api: request"})
+	db := file(entry{2, "db: query"}, entry{3, "db: rows"})
+	auth := file(entry{0, "auth: login"}, entry{5, "auth: token refreshed"})
+
+	byTS := func(a, b entry) int { return a.ts - b.ts }
+	for e := range MergeSortedChans(context.Background(), byTS, api, db, auth) {
+		fmt.Printf("  t=%d %s\n", e.ts, e.msg)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMergeSortedChans merges three sorted inputs of different lengths, one
+// empty, and checks the output is every value in globally sorted order.
+func TestMergeSortedChans(t *testing.T) {
+	a := generate(1, 4, 7, 10, 13)
+	b := generate(2, 2, 5, 20)
+	c := generate()
+	d := generate(0, 3, 6)
+
+	var got []int
+	for v := range MergeSortedChans(context.Background(), cmp.Compare[int], a, b, c, d) {
+		got = append(got, v)
+	}
+	want := []int{0, 1, 2, 2, 3, 4, 5, 6, 7, 10, 13, 20}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestMergeSortedChansCancel cancels a merge whose inputs never close while
+// the consumer has stopped reading, and checks the output closes.
+func TestMergeSortedChansCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	counter := func(start int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			for i := start; ; i += 2 {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch
+	}
+
+	out := MergeSortedChans(ctx, cmp.Compare[int], counter(0), counter(1))
+	for want := 0; want < 5; want++ {
+		if got := <-out; got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+	}
+	cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return // closed, as wanted
+			}
+		case <-timeout:
+			t.Fatal("output not closed within 1s of cancel")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Orchestrate is demoSelect + demoSelectNil generalised to a set of channels
+// that is only known at runtime. A select statement needs its cases at
+// compile time, so the loop uses reflect.Select over a []SelectCase:
+//
+//	case 0      → ctx.Done()  (cancellation always wins the next round)
+//	case 1..N   → one receive per named source
+//
+// When a source is closed its case's Chan is set to the zero reflect.Value —
+// the reflect equivalent of assigning nil to a channel variable — so it never
+// fires again. The loop ends when every source has drained.
+
+// Orchestrate receives from every channel in sources, calling onEach with the
+// source's name and each value, until all sources are closed (returns nil) or
+// ctx is cancelled (returns ctx.Err()). onEach runs on the caller's goroutine.
+func Orchestrate[T any](ctx context.Context, sources map[string]<-chan T, onEach func(name string, v T)) error {
+	cases := make([]reflect.SelectCase, 0, len(sources)+1)
+	names := make([]string, 0, len(sources)+1)
+
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	names = append(names, "") // placeholder for ctx.Done()
+
+	for name, ch := range sources {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+		names = append(names, name)
+	}
+
+	for open := len(sources); open > 0; {
+		i, v, ok := reflect.Select(cases)
+		if i == 0 {
+			return ctx.Err()
+		}
+		if !ok {
+			cases[i].Chan = reflect.Value{} // drained: disable this case
+			open--
+			continue
+		}
+		onEach(names[i], v.Interface().(T))
+
+		// reflect.Select picks randomly among ready cases; check ctx between
+		// values so a busy source cannot delay cancellation.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// demoOrchestrate merges three named sources of different lengths.
+func demoOrchestrate() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sources := map[string]<-chan int{
+		"fast":   generate(1, 2, 3, 4),
+		"medium": generate(10, 20),
+		"slow":   generate(100),
+	}
+
+	counts := map[string]int{}
+	err := Orchestrate(ctx, sources, func(name string, v int) {
+		counts[name]++
+		fmt.Printf("  %-6s → %d\n", name, v)
+	})
+	fmt.Printf("  all sources drained: err=%v counts=%v\n", err, counts)
+}
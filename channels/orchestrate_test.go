@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestOrchestrateDeliversAll checks that every value from every source is
+// delivered once, tagged with the right name, and in per-source order.
+func TestOrchestrateDeliversAll(t *testing.T) {
+	sources := map[string]<-chan int{
+		"a": generate(1, 2, 3, 4, 5),
+		"b": generate(10, 20),
+		"c": generate(),
+		"d": generate(100),
+	}
+
+	got := map[string][]int{}
+	err := Orchestrate(context.Background(), sources, func(name string, v int) {
+		got[name] = append(got[name], v)
+	})
+	if err != nil {
+		t.Fatalf("Orchestrate() = %v; want nil", err)
+	}
+
+	want := map[string][]int{
+		"a": {1, 2, 3, 4, 5},
+		"b": {10, 20},
+		"d": {100},
+	}
+	for name, w := range want {
+		if !slices.Equal(got[name], w) {
+			t.Errorf("source %q delivered %v; want %v", name, got[name], w)
+		}
+	}
+	if len(got["c"]) != 0 {
+		t.Errorf("empty source %q delivered %v", "c", got["c"])
+	}
+}
+
+// TestOrchestrateCancel checks that cancelling ctx stops the loop promptly
+// even though the sources are never closed.
+func TestOrchestrateCancel(t *testing.T) {
+	never := make(chan string) // never sends, never closed
+	busy := make(chan string)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case busy <- "tick":
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Orchestrate(ctx, map[string]<-chan string{"never": never, "busy": busy},
+		func(name string, v string) {})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Orchestrate() = %v; want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Orchestrate returned after %s; want prompt cancellation", elapsed)
+	}
+}
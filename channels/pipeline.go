@@ -81,6 +81,82 @@ func isPrime(n int) bool {
 	return true
 }
 
+// ── Cancellable pipeline ──────────────────────────────────────────────────────
+
+// cancellablePipeline wires gen → square → filter with a done channel: every
+// stage selects on done around each send, so closing done makes all three
+// goroutines exit even if the consumer stops reading midway. Without it, a
+// consumer that leaves early strands every stage blocked on a send.
+func cancellablePipeline(done <-chan struct{}, keep func(int) bool, nums ...int) <-chan int {
+	return filterStage(done, squareStage(done, genStage(done, nums...)), keep)
+}
+
+func genStage(done <-chan struct{}, nums ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, n := range nums {
+			select {
+			case out <- n:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func squareStage(done <-chan struct{}, in <-chan int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for n := range in {
+			select {
+			case out <- n * n:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func filterStage(done <-chan struct{}, in <-chan int, keep func(int) bool) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for n := range in {
+			if !keep(n) {
+				continue
+			}
+			select {
+			case out <- n:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// demoPipelineCancel reads the first three odd squares from a long pipeline
+// and then closes done; every stage exits without draining the input.
+func demoPipelineCancel() {
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i + 1
+	}
+	odd := func(n int) bool { return n%2 == 1 }
+
+	done := make(chan struct{})
+	out := cancellablePipeline(done, odd, nums...)
+	for i := 0; i < 3; i++ {
+		fmt.Printf("%d ", <-out)
+	}
+	close(done) // stop early: the stages return instead of blocking forever
+	fmt.Println("(cancelled)")
+}
+
 // ── Fan-out ───────────────────────────────────────────────────────────────────
 
 // demoFanOut distributes work from a single input channel across multiple
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+// TestCancellablePipelineOutput checks the full output when nothing is
+// cancelled: every even square, in input order, then the output closes.
+func TestCancellablePipelineOutput(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	var got []int
+	for v := range cancellablePipeline(done, isEven, 1, 2, 3, 4, 5, 6) {
+		got = append(got, v)
+	}
+	if want := []int{4, 16, 36}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestCancellablePipelineNoLeak starts pipelines over long inputs, reads a
+// few values from each, closes done and samples runtime.NumGoroutine until
+// every stage goroutine has exited.
+func TestCancellablePipelineNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	nums := make([]int, 10_000)
+	for i := range nums {
+		nums[i] = i
+	}
+
+	done := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		out := cancellablePipeline(done, isEven, nums...)
+		<-out
+		<-out // the stages are now blocked mid-stream
+	}
+	if n := runtime.NumGoroutine(); n < before+3*20 {
+		t.Fatalf("NumGoroutine = %d before cancel; want at least %d running stages", n, before+60)
+	}
+
+	close(done)
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("NumGoroutine = %d; want <= %d after close(done)", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats describes one RunProducerConsumer run.
+type Stats struct {
+	Produced int // values the producer emitted into the buffer
+	Consumed int // values the consumers processed; equals Produced on return
+
+	// MaxBufferOccupancy is the fullest the buffer was seen, sampled by the
+	// producer right after each send. It never exceeds bufSize; reaching it
+	// means the producer outran the consumers.
+	MaxBufferOccupancy int
+
+	// ProducerBlocked counts emits that found the buffer full and had to
+	// wait, ProducerWait the total time they waited: the backpressure the
+	// consumers put on the producer.
+	ProducerBlocked int
+	ProducerWait    time.Duration
+}
+
+// RunProducerConsumer wires produce to consumers goroutines running consume
+// through a channel of capacity bufSize, and returns once every emitted
+// value has been consumed.
+//
+// emit blocks while the buffer is full: a producer faster than its
+// consumers is slowed down to their pace instead of queueing without limit.
+// Once ctx is done emit discards its value, so produce should also watch ctx
+// and return. emit is not safe for concurrent use; call it from produce's
+// goroutine. Values already buffered when ctx is done are still consumed.
+func RunProducerConsumer[T any](ctx context.Context, produce func(ctx context.Context, emit func(T)), consume func(ctx context.Context, v T), bufSize, consumers int) Stats {
+	ch := make(chan T, bufSize)
+
+	var consumed atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < max(consumers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				consume(ctx, v)
+				consumed.Add(1)
+			}
+		}()
+	}
+
+	var st Stats
+	emit := func(v T) {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case ch <- v:
+		default: // full: this is backpressure
+			st.ProducerBlocked++
+			start := time.Now()
+			select {
+			case ch <- v:
+				st.ProducerWait += time.Since(start)
+			case <-ctx.Done():
+				st.ProducerWait += time.Since(start)
+				return
+			}
+		}
+		st.Produced++
+		st.MaxBufferOccupancy = max(st.MaxBufferOccupancy, len(ch))
+	}
+
+	produce(ctx, emit)
+	close(ch)
+	wg.Wait()
+	st.Consumed = int(consumed.Load())
+	return st
+}
+
+func demoProducerConsumer() {
+	const items = 50
+	produce := func(ctx context.Context, emit func(int)) {
+		for i := 0; i < items && ctx.Err() == nil; i++ {
+			emit(i) // as fast as it can
+		}
+	}
+	consume := func(ctx context.Context, v int) {
+		time.Sleep(2 * time.Millisecond) // slow consumer
+	}
+
+	for _, bufSize := range []int{0, 5, 100} {
+		start := time.Now()
+		st := RunProducerConsumer(context.Background(), produce, consume, bufSize, 2)
+		fmt.Printf("  buf=%-3d produced=%d consumed=%d maxOccupancy=%-3d blocked=%-2d wait=%-6v total=%v\n",
+			bufSize, st.Produced, st.Consumed, st.MaxBufferOccupancy, st.ProducerBlocked,
+			st.ProducerWait.Round(time.Millisecond), time.Since(start).Round(time.Millisecond))
+	}
+	fmt.Println("  a bigger buffer frees the producer sooner but the total is set by the consumers")
+}
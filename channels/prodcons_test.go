@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRunProducerConsumerBackpressure runs a fast producer against slow
+// consumers and checks every value is consumed, the buffer never holds more
+// than bufSize, and the producer was held back.
+func TestRunProducerConsumerBackpressure(t *testing.T) {
+	const items, bufSize = 100, 4
+
+	st := RunProducerConsumer(context.Background(),
+		func(ctx context.Context, emit func(int)) {
+			for i := 0; i < items; i++ {
+				emit(i)
+			}
+		},
+		func(ctx context.Context, v int) { time.Sleep(100 * time.Microsecond) },
+		bufSize, 3)
+
+	if st.Produced != items || st.Consumed != items {
+		t.Errorf("Produced, Consumed = %d, %d; want %d, %d", st.Produced, st.Consumed, items, items)
+	}
+	if st.MaxBufferOccupancy > bufSize {
+		t.Errorf("MaxBufferOccupancy = %d; want <= %d", st.MaxBufferOccupancy, bufSize)
+	}
+	if st.ProducerBlocked == 0 {
+		t.Error("ProducerBlocked = 0; want the fast producer to have waited on slow consumers")
+	}
+}
+
+// TestRunProducerConsumerCancel cancels ctx while the producer is blocked
+// on a full buffer and checks that the run returns with Consumed equal to
+// Produced.
+func TestRunProducerConsumerCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan Stats)
+	go func() {
+		done <- RunProducerConsumer(ctx,
+			func(ctx context.Context, emit func(int)) {
+				for i := 0; ctx.Err() == nil; i++ { // endless until cancelled
+					emit(i)
+				}
+			},
+			func(ctx context.Context, v int) { time.Sleep(5 * time.Millisecond) },
+			2, 1)
+	}()
+
+	select {
+	case st := <-done:
+		if st.Produced == 0 || st.Consumed != st.Produced {
+			t.Errorf("Produced, Consumed = %d, %d; want equal and > 0", st.Produced, st.Consumed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunProducerConsumer did not return after ctx was cancelled")
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ReplayHub is a broadcast pub/sub that also remembers the last N events.
+// Every subscriber gets its own buffered channel; Publish copies each event
+// into all of them. A new subscriber first receives the retained history,
+// oldest first, and then live events — in one ordered stream, because the
+// history is written into its channel under the same lock Publish takes.
+//
+// Publish never blocks on a slow subscriber: if a subscriber's buffer is
+// full, the event is dropped for that subscriber only (Dropped counts them).
+// Size the buffer for the burst a consumer must absorb.
+type ReplayHub[T any] struct {
+	mu      sync.Mutex
+	subs    map[int]chan T
+	nextID  int
+	buffer  int
+	closed  bool
+	dropped int
+
+	// ring holds the last len(ring) events; start is the oldest, count how
+	// many slots are filled.
+	ring  []T
+	start int
+	count int
+}
+
+// NewReplayHub returns a hub that replays the last history events to new
+// subscribers and gives each subscriber a channel of capacity buffer (plus
+// room for the replayed history).
+func NewReplayHub[T any](history, buffer int) *ReplayHub[T] {
+	return &ReplayHub[T]{
+		subs:   make(map[int]chan T),
+		buffer: buffer,
+		ring:   make([]T, history),
+	}
+}
+
+// Publish records v in the history and delivers it to every subscriber.
+// Publishing on a closed hub is a no-op.
+func (h *ReplayHub[T]) Publish(v T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	if n := len(h.ring); n > 0 {
+		if h.count < n {
+			h.ring[(h.start+h.count)%n] = v
+			h.count++
+		} else {
+			h.ring[h.start] = v // overwrite the oldest
+			h.start = (h.start + 1) % n
+		}
+	}
+
+	for _, ch := range h.subs {
+		select {
+		case ch <- v:
+		default:
+			h.dropped++
+		}
+	}
+}
+
+// Subscribe returns a channel that yields the retained history followed by
+// live events, and a function that unsubscribes and closes the channel.
+// On a closed hub the channel still yields the history and is already
+// closed.
+func (h *ReplayHub[T]) Subscribe() (<-chan T, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan T, h.count+h.buffer)
+	for i := 0; i < h.count; i++ {
+		ch <- h.ring[(h.start+i)%len(h.ring)]
+	}
+
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+
+	id := h.nextID
+	h.nextID++
+	h.subs[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if c, ok := h.subs[id]; ok {
+				delete(h.subs, id)
+				close(c)
+			}
+		})
+	}
+}
+
+// Dropped reports how many deliveries were skipped because a subscriber's
+// buffer was full.
+func (h *ReplayHub[T]) Dropped() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.dropped
+}
+
+// Close closes every subscriber channel. Later Publish calls are ignored.
+func (h *ReplayHub[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for id, ch := range h.subs {
+		delete(h.subs, id)
+		close(ch)
+	}
+}
+
+func demoReplayHub() {
+	hub := NewReplayHub[string](3, 8)
+
+	early, unsubEarly := hub.Subscribe()
+	defer unsubEarly()
+
+	for _, e := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		hub.Publish(e)
+	}
+
+	// Joins late: gets the last 3 events, then live ones.
+	late, unsubLate := hub.Subscribe()
+	defer unsubLate()
+
+	hub.Publish("e6")
+	hub.Close()
+
+	collect := func(ch <-chan string) []string {
+		var out []string
+		for v := range ch {
+			out = append(out, v)
+		}
+		return out
+	}
+	fmt.Println("  early subscriber:", collect(early))
+	fmt.Println("  late subscriber: ", collect(late), "(e3..e5 replayed, e6 live)")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// drain reads n values from ch, failing the test if it closes early.
+func drain[T any](t *testing.T, ch <-chan T, n int) []T {
+	t.Helper()
+	out := make([]T, 0, n)
+	for len(out) < n {
+		v, ok := <-ch
+		if !ok {
+			t.Fatalf("channel closed after %d values; want %d", len(out), n)
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// TestReplayHubLateSubscriber publishes more events than the history holds,
+// then subscribes and checks that the last N arrive first, in order, followed
+// by live events.
+func TestReplayHubLateSubscriber(t *testing.T) {
+	hub := NewReplayHub[int](3, 4)
+	defer hub.Close()
+
+	for i := 1; i <= 5; i++ {
+		hub.Publish(i)
+	}
+
+	ch, unsub := hub.Subscribe()
+	defer unsub()
+
+	hub.Publish(6)
+	hub.Publish(7)
+
+	got := drain(t, ch, 5)
+	if want := []int{3, 4, 5, 6, 7}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestReplayHubPartialHistory checks replay before the ring has wrapped.
+func TestReplayHubPartialHistory(t *testing.T) {
+	hub := NewReplayHub[string](5, 1)
+	defer hub.Close()
+
+	hub.Publish("a")
+	hub.Publish("b")
+
+	ch, unsub := hub.Subscribe()
+	defer unsub()
+	hub.Publish("c")
+
+	if got, want := drain(t, ch, 3), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestReplayHubUnsubscribeAndClose checks that unsubscribe and Close both
+// close the subscriber channel, and that a full buffer drops instead of
+// blocking Publish.
+func TestReplayHubUnsubscribeAndClose(t *testing.T) {
+	hub := NewReplayHub[int](0, 1)
+
+	a, unsubA := hub.Subscribe()
+	b, _ := hub.Subscribe()
+
+	hub.Publish(1)
+	hub.Publish(2) // buffer of 1: dropped for both subscribers
+
+	if d := hub.Dropped(); d != 2 {
+		t.Errorf("Dropped() = %d; want 2", d)
+	}
+
+	unsubA()
+	unsubA() // idempotent
+	if got := drain(t, a, 1); got[0] != 1 {
+		t.Errorf("a got %v; want [1]", got)
+	}
+	if _, ok := <-a; ok {
+		t.Error("a still open after unsubscribe")
+	}
+
+	hub.Close()
+	if got := drain(t, b, 1); got[0] != 1 {
+		t.Errorf("b got %v; want [1]", got)
+	}
+	if _, ok := <-b; ok {
+		t.Error("b still open after Close")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RoundRobin multiplexes chans into one channel, serving them in strict
+// rotation: after a value from chans[i] it next tries chans[i+1], skipping
+// any that have nothing ready. Every busy input gets the same share no matter
+// how fast it produces, unlike a plain select, which picks randomly among
+// ready cases and so is fair only on average. When no input is ready it
+// blocks until one is.
+//
+// The output closes once every input is closed and drained, or when ctx is
+// done.
+func RoundRobin[T any](ctx context.Context, chans []<-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		n := len(chans)
+		closed := make([]bool, n)
+		open := n
+		next := 0 // the input to try first
+
+		emit := func(i int, v T) bool {
+			next = (i + 1) % n
+			select {
+			case out <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for open > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// One pass over the rotation without blocking.
+			served := false
+			for k := 0; k < n && !served; k++ {
+				i := (next + k) % n
+				if closed[i] {
+					continue
+				}
+				select {
+				case v, ok := <-chans[i]:
+					if !ok {
+						closed[i] = true
+						open--
+						continue
+					}
+					if !emit(i, v) {
+						return
+					}
+					served = true
+				default:
+				}
+			}
+			if served || open == 0 {
+				continue
+			}
+
+			// Nothing ready: wait for any open input (or ctx), as Orchestrate
+			// does, then resume the rotation after it.
+			cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}}
+			idx := []int{-1}
+			for i, ch := range chans {
+				if !closed[i] {
+					cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+					idx = append(idx, i)
+				}
+			}
+			c, v, ok := reflect.Select(cases)
+			switch {
+			case c == 0:
+				return
+			case !ok:
+				closed[idx[c]] = true
+				open--
+			default:
+				if !emit(idx[c], v.Interface().(T)) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// demoRoundRobin compares a plain select with RoundRobin over three inputs
+// that always have a value ready.
+func demoRoundRobin() {
+	ready := func(name string) <-chan string {
+		ch := make(chan string, 12)
+		for i := 0; i < 12; i++ {
+			ch <- name
+		}
+		close(ch)
+		return ch
+	}
+
+	a, b, c := ready("a"), ready("b"), ready("c")
+	fmt.Print("  select:     ")
+	for i := 0; i < 12; i++ {
+		select { // random among ready cases
+		case v := <-a:
+			fmt.Print(v)
+		case v := <-b:
+			fmt.Print(v)
+		case v := <-c:
+			fmt.Print(v)
+		}
+	}
+	fmt.Println()
+
+	fmt.Print("  RoundRobin: ")
+	rr := RoundRobin(context.Background(), []<-chan string{ready("a"), ready("b"), ready("c")})
+	for i := 0; i < 12; i++ {
+		fmt.Print(<-rr)
+	}
+	fmt.Println()
+	for range rr { // drain so the multiplexer exits
+	}
+}
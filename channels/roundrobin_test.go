@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// TestRoundRobinStrictRotation feeds buffered, closed inputs of different
+// lengths and checks the exact interleaving: a drained input drops out of
+// the rotation and the output closes after the last value.
+func TestRoundRobinStrictRotation(t *testing.T) {
+	filled := func(vs ...string) <-chan string {
+		ch := make(chan string, len(vs))
+		for _, v := range vs {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+
+	var got []string
+	for v := range RoundRobin(context.Background(), []<-chan string{
+		filled("a1", "a2", "a3"),
+		filled("b1"),
+		filled("c1", "c2"),
+	}) {
+		got = append(got, v)
+	}
+	if want := []string{"a1", "b1", "c1", "a2", "c2", "a3"}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestRoundRobinFairWithBusyProducers reads many values from three producers
+// that are always ready to send and checks each got about a third of them.
+func TestRoundRobinFairWithBusyProducers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	producer := func(id int) <-chan int {
+		ch := make(chan int, 4)
+		go func() {
+			defer close(ch)
+			for {
+				select {
+				case ch <- id:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch
+	}
+
+	const reads = 3000
+	out := RoundRobin(ctx, []<-chan int{producer(0), producer(1), producer(2)})
+	var counts [3]int
+	for i := 0; i < reads; i++ {
+		counts[<-out]++
+	}
+
+	for id, n := range counts {
+		if n < reads/3*9/10 || n > reads/3*11/10 {
+			t.Errorf("producer %d served %d times; want about %d (counts %v)", id, n, reads/3, counts)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SafeChan is a channel that several goroutines may send on and close
+// without coordinating: Send after Close returns false instead of panicking,
+// and Close is idempotent. It exists for the cases the rules at the end of
+// the README forbid — several senders, none of which owns the channel.
+//
+// Send holds a read lock while it sends, so Close (which takes the write
+// lock to close ch) never runs while a send is in progress. A Send blocked
+// on a full channel would hold Close off forever, so Close first closes
+// done, which aborts every blocked Send.
+type SafeChan[T any] struct {
+	ch   chan T
+	done chan struct{}
+	once sync.Once
+
+	mu     sync.RWMutex
+	closed bool // guarded by mu
+}
+
+// NewSafeChan returns an open SafeChan with the given buffer size.
+func NewSafeChan[T any](buffer int) *SafeChan[T] {
+	return &SafeChan[T]{
+		ch:   make(chan T, buffer),
+		done: make(chan struct{}),
+	}
+}
+
+// Send sends v, blocking while the buffer is full, and reports whether v was
+// sent: false if the channel is closed, or closed while Send waited.
+func (c *SafeChan[T]) Send(v T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return false
+	}
+	select {
+	case c.ch <- v:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+// Close closes the channel. Values already sent stay readable; calling Close
+// again is a no-op.
+func (c *SafeChan[T]) Close() {
+	c.once.Do(func() {
+		close(c.done) // wake blocked senders so they release the read lock
+		c.mu.Lock()
+		c.closed = true
+		close(c.ch)
+		c.mu.Unlock()
+	})
+}
+
+// Recv receives the next value, like v, ok := <-ch: ok is false once the
+// channel is closed and drained.
+func (c *SafeChan[T]) Recv() (T, bool) {
+	v, ok := <-c.ch
+	return v, ok
+}
+
+// C returns the underlying channel for range and select. Only receive from
+// it: Send and Close are the only safe ways to write.
+func (c *SafeChan[T]) C() <-chan T {
+	return c.ch
+}
+
+func demoSafeChan() {
+	c := NewSafeChan[int](1)
+
+	// Three producers, none of which owns the channel; a consumer that
+	// closes it after the first two values.
+	var wg sync.WaitGroup
+	for p := 1; p <= 3; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				if !c.Send(p*100 + i) {
+					fmt.Printf("  producer %d: Send returned false, stopping\n", p)
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}(p)
+	}
+
+	for i := 0; i < 2; i++ {
+		v, _ := c.Recv()
+		fmt.Println("  received", v)
+	}
+	c.Close()
+	c.Close() // no panic
+	wg.Wait()
+
+	n := 0
+	for range c.C() {
+		n++ // values sent before Close are still delivered
+	}
+	fmt.Printf("  drained %d buffered value(s) after Close\n", n)
+}
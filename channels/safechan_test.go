@@ -0,0 +1,59 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSafeChanSendRecv checks that values sent before Close are received in
+// order, and that Recv reports the close once they are drained.
+func TestSafeChanSendRecv(t *testing.T) {
+	c := NewSafeChan[int](2)
+	if !c.Send(1) || !c.Send(2) {
+		t.Fatal("Send on an open channel returned false")
+	}
+	for _, want := range []int{1, 2} {
+		if v, ok := c.Recv(); !ok || v != want {
+			t.Errorf("Recv = %v, %v; want %v, true", v, ok, want)
+		}
+	}
+
+	c.Send(3)
+	c.Close()
+	if v, ok := c.Recv(); !ok || v != 3 {
+		t.Errorf("Recv after Close = %v, %v; want the buffered 3, true", v, ok)
+	}
+	if v, ok := c.Recv(); ok {
+		t.Errorf("Recv on drained closed channel = %v, true; want ok = false", v)
+	}
+}
+
+// TestSafeChanCloseTwice checks that a second Close does not panic and that
+// Send after Close returns false instead of panicking.
+func TestSafeChanCloseTwice(t *testing.T) {
+	c := NewSafeChan[string](1)
+	c.Close()
+	c.Close()
+	if c.Send("late") {
+		t.Error("Send after Close = true; want false")
+	}
+}
+
+// TestSafeChanCloseUnblocksSend checks that Close wakes a Send blocked on a
+// full channel, which then returns false.
+func TestSafeChanCloseUnblocksSend(t *testing.T) {
+	c := NewSafeChan[int](0) // unbuffered, nobody receiving
+	sent := make(chan bool)
+	go func() { sent <- c.Send(1) }()
+
+	time.Sleep(10 * time.Millisecond) // let Send block
+	c.Close()
+	select {
+	case ok := <-sent:
+		if ok {
+			t.Error("blocked Send = true after Close; want false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not unblock Send")
+	}
+}
@@ -116,4 +116,29 @@ func demoSelectTimeout() {
 	case <-time.After(100 * time.Millisecond):
 		fmt.Println("timeout: slow channel didn't respond in time")
 	}
+
+	// The same race, packaged: RecvTimeout.
+	fast := make(chan string, 1)
+	fast <- "cached"
+	v, ok := RecvTimeout(fast, 100*time.Millisecond)
+	fmt.Printf("RecvTimeout(fast) = %q, %v\n", v, ok)
+	v, ok = RecvTimeout(slow, 50*time.Millisecond) // sender still ~50 ms away
+	fmt.Printf("RecvTimeout(slow) = %q, %v\n", v, ok)
+}
+
+// RecvTimeout receives from ch, giving up after d. It returns the value and
+// true, or the zero value and false if d elapses first or ch is closed.
+//
+// Unlike time.After, the timer is stopped as soon as a value arrives, so a
+// RecvTimeout in a hot loop does not leave timers pending until they fire.
+func RecvTimeout[T any](ch <-chan T, d time.Duration) (T, bool) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case v, ok := <-ch:
+		return v, ok
+	case <-t.C:
+		var zero T
+		return zero, false
+	}
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecvTimeoutValue sends a value before the timeout and checks
+// RecvTimeout returns it with true.
+func TestRecvTimeoutValue(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		ch <- 42
+	}()
+
+	v, ok := RecvTimeout(ch, time.Second)
+	if v != 42 || !ok {
+		t.Errorf("RecvTimeout = %d, %v; want 42, true", v, ok)
+	}
+}
+
+// TestRecvTimeoutExpires checks that with no sender RecvTimeout returns the
+// zero value and false, and does so close to d.
+func TestRecvTimeoutExpires(t *testing.T) {
+	ch := make(chan string)
+	const d = 20 * time.Millisecond
+
+	start := time.Now()
+	v, ok := RecvTimeout(ch, d)
+	elapsed := time.Since(start)
+
+	if v != "" || ok {
+		t.Errorf("RecvTimeout = %q, %v; want \"\", false", v, ok)
+	}
+	if elapsed < d || elapsed > time.Second {
+		t.Errorf("RecvTimeout returned after %v; want about %v", elapsed, d)
+	}
+}
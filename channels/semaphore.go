@@ -1,46 +1,108 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// demoSemaphore shows how a buffered channel acts as a counting semaphore:
-// at most N goroutines run concurrently at any time.
+// ChanSemaphore is a counting semaphore on a buffered channel — one token
+// per slot — with a gauge of the slots in use and their high-watermark:
 //
 //	Acquire: sem <- struct{}{}  (blocks when buffer is full)
 //	Release: <-sem              (always in a defer)
 //
+// It is safe for concurrent use.
+type ChanSemaphore struct {
+	sem   chan struct{}
+	inUse atomic.Int32
+	peak  atomic.Int32
+}
+
+// NewChanSemaphore returns a semaphore with limit (>= 1) slots.
+func NewChanSemaphore(limit int) *ChanSemaphore {
+	return &ChanSemaphore{sem: make(chan struct{}, max(limit, 1))}
+}
+
+// Acquire takes a slot, blocking while all of them are in use. It returns
+// ctx.Err() without a slot if ctx is done first.
+func (s *ChanSemaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	n := s.inUse.Add(1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			return nil
+		}
+	}
+}
+
+// Release gives back a slot taken by Acquire. Releasing a slot nobody holds
+// panics, like unlocking an unlocked Mutex, and is detected before anything
+// changes, so the gauge never goes negative. The gauge is lowered before the
+// slot is freed, so InUse never exceeds the limit.
+func (s *ChanSemaphore) Release() {
+	for {
+		n := s.inUse.Load()
+		if n <= 0 {
+			panic("ChanSemaphore: Release without Acquire")
+		}
+		if s.inUse.CompareAndSwap(n, n-1) {
+			break
+		}
+	}
+	<-s.sem // Acquire fills a slot before raising the gauge, so one is held
+}
+
+// InUse returns the number of slots currently held.
+func (s *ChanSemaphore) InUse() int { return int(s.inUse.Load()) }
+
+// Peak returns the most slots ever held at once.
+func (s *ChanSemaphore) Peak() int { return int(s.peak.Load()) }
+
+// Limit returns the number of slots.
+func (s *ChanSemaphore) Limit() int { return cap(s.sem) }
+
+// demoSemaphore shows how a buffered channel acts as a counting semaphore:
+// at most N goroutines run concurrently at any time.
+//
 // This is simpler and more composable than sync.Mutex for rate-limiting
 // concurrency without a worker pool.
 func demoSemaphore() {
 	const maxConcurrent = 3
 	const totalTasks = 9
 
-	sem := make(chan struct{}, maxConcurrent)
-
-	var (
-		wg      sync.WaitGroup
-		running atomic.Int32
-	)
+	sem := NewChanSemaphore(maxConcurrent)
+	var wg sync.WaitGroup
 
 	for i := 1; i <= totalTasks; i++ {
 		wg.Add(1)
 		go func(id int) {
 			defer wg.Done()
 
-			sem <- struct{}{} // acquire: blocks if maxConcurrent goroutines are running
-			defer func() { <-sem }() // release
+			if err := sem.Acquire(context.Background()); err != nil { // blocks if maxConcurrent goroutines are running
+				return
+			}
+			defer sem.Release()
 
-			n := running.Add(1)
-			fmt.Printf("  task%d started  (concurrent: %d)\n", id, n)
+			fmt.Printf("  task%d started  (in use: %d)\n", id, sem.InUse())
 			time.Sleep(30 * time.Millisecond)
-			running.Add(-1)
 			fmt.Printf("  task%d finished\n", id)
 		}(i)
 	}
 
+	// A caller that will not wait more than 10ms for a slot.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	time.Sleep(5 * time.Millisecond) // let the tasks take every slot
+	fmt.Printf("  impatient Acquire: %v\n", sem.Acquire(ctx))
+
 	wg.Wait()
+	fmt.Printf("  peak: %d of %d slots, in use now: %d\n", sem.Peak(), sem.Limit(), sem.InUse())
 }
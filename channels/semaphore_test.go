@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestChanSemaphoreBoundsConcurrency runs many more goroutines than the
+// limit, sampling InUse while each holds a slot, and checks it never
+// exceeds the limit, Peak reaches it, and everything is released at the end.
+func TestChanSemaphoreBoundsConcurrency(t *testing.T) {
+	const limit, goroutines = 4, 40
+
+	sem := NewChanSemaphore(limit)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background()); err != nil {
+				t.Errorf("Acquire: %v", err)
+				return
+			}
+			defer sem.Release()
+			if n := sem.InUse(); n > limit {
+				t.Errorf("InUse = %d; want <= %d", n, limit)
+			}
+			time.Sleep(time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	if got := sem.Peak(); got != limit {
+		t.Errorf("Peak = %d; want %d", got, limit)
+	}
+	if got := sem.InUse(); got != 0 {
+		t.Errorf("InUse after all released = %d; want 0", got)
+	}
+}
+
+// TestChanSemaphoreAcquireHonoursContext checks that Acquire on a full
+// semaphore gives up when ctx expires, without taking a slot.
+func TestChanSemaphoreAcquireHonoursContext(t *testing.T) {
+	sem := NewChanSemaphore(1)
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire on full semaphore = %v; want context.DeadlineExceeded", err)
+	}
+	if got := sem.InUse(); got != 1 {
+		t.Errorf("InUse = %d; want 1 (the failed Acquire took nothing)", got)
+	}
+}
+
+// TestChanSemaphoreOverRelease checks that a Release without Acquire panics
+// and leaves the semaphore as it was: InUse unchanged and every slot usable.
+func TestChanSemaphoreOverRelease(t *testing.T) {
+	sem := NewChanSemaphore(2)
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	sem.Release()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Release without Acquire did not panic")
+			}
+		}()
+		sem.Release()
+	}()
+	if got := sem.InUse(); got != 0 {
+		t.Errorf("InUse after the panic = %d; want 0", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < sem.Limit(); i++ {
+		if err := sem.Acquire(ctx); err != nil {
+			t.Fatalf("Acquire %d after the panic: %v", i+1, err)
+		}
+	}
+	if got := sem.InUse(); got != sem.Limit() {
+		t.Errorf("InUse = %d; want %d", got, sem.Limit())
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WindowBy groups the values from in into tumbling time windows: every
+// window it emits a slice with the values that arrived since the previous
+// emission. Empty windows emit nothing. When in closes, the partial window
+// is flushed and the output is closed, so the consumer should range over it
+// until it closes.
+//
+// When ctx is done the partial window is flushed too, but the send waits at
+// most one more window for the consumer, which may have stopped reading
+// along with ctx; after that the window is dropped and the output closed.
+func WindowBy[T any](ctx context.Context, in <-chan T, window time.Duration) <-chan []T {
+	out := make(chan []T)
+	ticker := time.NewTicker(window)
+	go func() {
+		defer ticker.Stop()
+		windowLoop(ctx, in, ticker.C, window, out)
+	}()
+	return out
+}
+
+// windowLoop does the work of WindowBy with the window boundaries supplied by
+// tick, so tests can drive them by hand; grace bounds the flush after ctx is
+// done. It closes out when it returns.
+func windowLoop[T any](ctx context.Context, in <-chan T, tick <-chan time.Time, grace time.Duration, out chan<- []T) {
+	defer close(out)
+
+	var batch []T
+	// send emits batch, giving up if ctx is done first.
+	send := func() bool {
+		select {
+		case out <- batch:
+			batch = nil
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	// flushCancelled emits the partial window once ctx is done. ctx.Done()
+	// cannot bound this send: it is already closed, and select would pick
+	// between it and a ready consumer at random. A timer bounds it instead.
+	flushCancelled := func() {
+		if len(batch) == 0 {
+			return
+		}
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case out <- batch:
+		case <-timer.C:
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCancelled()
+			return
+		case v, ok := <-in:
+			if !ok {
+				if len(batch) > 0 && !send() {
+					flushCancelled()
+				}
+				return
+			}
+			batch = append(batch, v)
+		case <-tick:
+			if len(batch) > 0 && !send() {
+				flushCancelled()
+				return
+			}
+		}
+	}
+}
+
+// demoWindowBy batches a stream of events into 50ms windows.
+func demoWindowBy() {
+	events := make(chan string)
+	go func() {
+		defer close(events)
+		for i := 1; i <= 7; i++ {
+			events <- fmt.Sprintf("e%d", i)
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	for batch := range WindowBy(context.Background(), events, 50*time.Millisecond) {
+		fmt.Printf("  window: %v\n", batch)
+	}
+}
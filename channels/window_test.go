@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestWindowLoopGroupsByWindow drives the window boundaries with a manual
+// tick channel and checks that each emitted batch holds exactly the items
+// sent within that window, that empty windows emit nothing, and that
+// cancelling ctx flushes the partial window and closes the output.
+func TestWindowLoopGroupsByWindow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	tick := make(chan time.Time)
+	out := make(chan []int)
+	go windowLoop(ctx, in, tick, time.Second, out)
+
+	recv := func() []int {
+		t.Helper()
+		select {
+		case b := <-out:
+			return b
+		case <-time.After(time.Second):
+			t.Fatal("no batch within 1s")
+			return nil
+		}
+	}
+
+	in <- 1
+	in <- 2
+	tick <- time.Time{}
+	if got, want := recv(), []int{1, 2}; !slices.Equal(got, want) {
+		t.Errorf("window 1: got %v; want %v", got, want)
+	}
+
+	in <- 3
+	tick <- time.Time{}
+	if got, want := recv(), []int{3}; !slices.Equal(got, want) {
+		t.Errorf("window 2: got %v; want %v", got, want)
+	}
+
+	tick <- time.Time{} // empty window: nothing is emitted
+	in <- 4
+	in <- 5
+	cancel()
+	if got, want := recv(), []int{4, 5}; !slices.Equal(got, want) {
+		t.Errorf("flush on cancel: got %v; want %v", got, want)
+	}
+	if b, ok := <-out; ok {
+		t.Errorf("received %v after the flush; want the output closed", b)
+	}
+}
+
+// TestWindowLoopCancelWithoutReader cancels ctx with a partial window pending
+// and nobody reading the output, and checks the loop gives up on the flush
+// after the grace period instead of blocking forever.
+func TestWindowLoopCancelWithoutReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := make(chan []int)
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		windowLoop(ctx, in, nil, 10*time.Millisecond, out)
+	}()
+
+	in <- 1
+	cancel()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("windowLoop still blocked on the flush 1s after cancel (grace 10ms)")
+	}
+}
+
+// TestWindowByFlushesOnClose checks the real-clock wrapper: with a window far
+// longer than the test, closing the input still emits everything once.
+func TestWindowByFlushesOnClose(t *testing.T) {
+	in := make(chan string, 3)
+	in <- "a"
+	in <- "b"
+	in <- "c"
+	close(in)
+
+	var got [][]string
+	for b := range WindowBy(context.Background(), in, time.Hour) {
+		got = append(got, b)
+	}
+	if len(got) != 1 || !slices.Equal(got[0], []string{"a", "b", "c"}) {
+		t.Errorf("got %v; want [[a b c]]", got)
+	}
+}
@@ -24,6 +24,9 @@ func main() {
 	section("Propagation: parent cancels all children")
 	demoPropagation()
 
+	section("ShutdownGroup: apagar varios subsistemas con un deadline")
+	demoShutdownGroup()
+
 	section("HTTP server & client")
 	demoHTTP()
 }
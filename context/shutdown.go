@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShutdownGroup coordinates the shutdown of several subsystems (HTTP server,
+// worker pool, tickers) under one deadline. The zero value is ready to use.
+type ShutdownGroup struct {
+	// Logger reports each subsystem's outcome. If nil, log.Default() is used.
+	Logger *log.Logger
+
+	mu    sync.Mutex
+	names []string
+	fns   []func(ctx context.Context) error
+}
+
+// Register adds a subsystem. fn should stop it and return once done or once
+// ctx is done, whichever comes first. It is safe to call concurrently.
+func (g *ShutdownGroup) Register(name string, fn func(ctx context.Context) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.names = append(g.names, name)
+	g.fns = append(g.fns, fn)
+}
+
+// Shutdown runs every registered func concurrently with ctx and waits until
+// they all return or ctx is done. Subsystems still running at that point are
+// logged and reported as timed out; Shutdown does not wait for them.
+//
+// The result joins (errors.Join) one error per failed or timed-out
+// subsystem, in registration order, each prefixed with its name. A timeout
+// wraps ctx.Err(), so errors.Is(err, context.DeadlineExceeded) holds. It
+// returns nil if every subsystem shut down cleanly in time.
+func (g *ShutdownGroup) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	names := append([]string(nil), g.names...)
+	fns := append([]func(context.Context) error(nil), g.fns...)
+	g.mu.Unlock()
+
+	logger := g.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	type result struct {
+		i   int
+		err error
+	}
+	// Buffered so a subsystem finishing after the deadline never blocks.
+	results := make(chan result, len(fns))
+	for i, fn := range fns {
+		go func(i int, fn func(context.Context) error) {
+			results <- result{i, fn(ctx)}
+		}(i, fn)
+	}
+
+	errs := make([]error, len(fns))
+	finished := make([]bool, len(fns))
+	for remaining := len(fns); remaining > 0; remaining-- {
+		select {
+		case r := <-results:
+			finished[r.i] = true
+			if r.err != nil {
+				errs[r.i] = fmt.Errorf("%s: %w", names[r.i], r.err)
+				logger.Printf("[shutdown] %s failed: %v", names[r.i], r.err)
+			} else {
+				logger.Printf("[shutdown] %s stopped", names[r.i])
+			}
+		case <-ctx.Done():
+			for i, done := range finished {
+				if !done {
+					errs[i] = fmt.Errorf("%s: timed out: %w", names[i], ctx.Err())
+					logger.Printf("[shutdown] %s timed out", names[i])
+				}
+			}
+			return errors.Join(errs...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// demoShutdownGroup stops three subsystems under a 100ms budget: one quick,
+// one that fails, one that ignores ctx and is reported as timed out.
+func demoShutdownGroup() {
+	g := &ShutdownGroup{Logger: log.New(os.Stdout, "  ", 0)}
+	g.Register("http", func(ctx context.Context) error {
+		return fakeHTTPCall(ctx, 10*time.Millisecond) // drain in-flight requests
+	})
+	g.Register("cache", func(ctx context.Context) error {
+		return errors.New("flush: disk full")
+	})
+	g.Register("worker-pool", func(ctx context.Context) error {
+		time.Sleep(500 * time.Millisecond) // a long job that ignores ctx
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := g.Shutdown(ctx)
+	fmt.Printf("  Shutdown error:\n    %v\n", strings.ReplaceAll(err.Error(), "\n", "\n    "))
+	fmt.Println("  errors.Is(err, DeadlineExceeded):", errors.Is(err, context.DeadlineExceeded))
+}
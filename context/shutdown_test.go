@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestShutdownGroupReportsSlowSubsystem shuts down a fast and a slow
+// subsystem under a short deadline and checks that only the slow one is
+// reported — in the error and in the log — as timed out.
+func TestShutdownGroupReportsSlowSubsystem(t *testing.T) {
+	var logs bytes.Buffer
+	g := &ShutdownGroup{Logger: log.New(&logs, "", 0)}
+	g.Register("fast", func(ctx context.Context) error { return nil })
+	g.Register("slow", func(ctx context.Context) error {
+		time.Sleep(time.Second) // ignores ctx
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := g.Shutdown(ctx)
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Shutdown took %v; want it to return at the deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v; want it to wrap context.DeadlineExceeded", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "slow: timed out") || strings.Contains(msg, "fast") {
+		t.Errorf("err = %q; want only slow reported as timed out", msg)
+	}
+	if out := logs.String(); !strings.Contains(out, "slow timed out") || !strings.Contains(out, "fast stopped") {
+		t.Errorf("log = %q; want fast stopped and slow timed out", out)
+	}
+}
+
+// TestShutdownGroupJoinsErrors checks that failures are joined with their
+// subsystem names and remain matchable with errors.Is, and that a clean
+// shutdown returns nil.
+func TestShutdownGroupJoinsErrors(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	g := &ShutdownGroup{Logger: log.New(&bytes.Buffer{}, "", 0)}
+	g.Register("ok", func(ctx context.Context) error { return nil })
+	g.Register("cache", func(ctx context.Context) error { return errFlush })
+
+	err := g.Shutdown(context.Background())
+	if !errors.Is(err, errFlush) {
+		t.Errorf("err = %v; want it to wrap errFlush", err)
+	}
+	if got, want := err.Error(), "cache: flush failed"; got != want {
+		t.Errorf("err = %q; want %q", got, want)
+	}
+
+	var clean ShutdownGroup
+	clean.Logger = log.New(&bytes.Buffer{}, "", 0)
+	clean.Register("ok", func(ctx context.Context) error { return nil })
+	if err := clean.Shutdown(context.Background()); err != nil {
+		t.Errorf("clean Shutdown = %v; want nil", err)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -31,6 +32,37 @@ func demoTimeout() {
 	if ok {
 		fmt.Printf("time until deadline: %v\n", time.Until(deadline).Round(time.Millisecond))
 	}
+
+	// Case 4: reusable wrapper — the error says which budget was exceeded.
+	_, err = DoWithTimeout(context.Background(), 50*time.Millisecond,
+		func(ctx context.Context) (string, error) {
+			return "pong", fakeHTTPCall(ctx, 300*time.Millisecond)
+		})
+	fmt.Println("DoWithTimeout (300ms, timeout 50ms):", orOK(err))
+	fmt.Println("  errors.Is(err, DeadlineExceeded):", errors.Is(err, context.DeadlineExceeded))
+}
+
+// DoWithTimeout runs fn under a context that expires after d.
+//
+// If the timeout fires, the returned error wraps context.DeadlineExceeded
+// and names the configured duration ("timed out after 50ms: context deadline
+// exceeded"), which is far easier to act on than the bare sentinel.
+//
+// The timeout is attached as a cause (WithTimeoutCause), so only our own
+// timer is reported this way: if the parent is cancelled or hits its own
+// deadline first, fn's error is returned unchanged.
+func DoWithTimeout[T any](parent context.Context, d time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	errTimeout := fmt.Errorf("timed out after %s: %w", d, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithTimeoutCause(parent, d, errTimeout)
+	defer cancel()
+
+	v, err := fn(ctx)
+	if err != nil && context.Cause(ctx) == errTimeout {
+		var zero T
+		return zero, errTimeout
+	}
+	return v, err
 }
 
 // fakeHTTPCall simulates an outbound call that respects context cancellation.
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDoWithTimeoutInTime checks that a fast fn returns its value untouched.
+func TestDoWithTimeoutInTime(t *testing.T) {
+	got, err := DoWithTimeout(context.Background(), time.Second,
+		func(ctx context.Context) (string, error) {
+			return "pong", fakeHTTPCall(ctx, 5*time.Millisecond)
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "pong" {
+		t.Errorf("got %q; want %q", got, "pong")
+	}
+}
+
+// TestDoWithTimeoutExceeded checks that a slow fn yields an error that wraps
+// context.DeadlineExceeded and mentions the configured duration.
+func TestDoWithTimeoutExceeded(t *testing.T) {
+	const d = 20 * time.Millisecond
+
+	got, err := DoWithTimeout(context.Background(), d,
+		func(ctx context.Context) (string, error) {
+			return "pong", fakeHTTPCall(ctx, time.Second)
+		})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v; want errors.Is(err, context.DeadlineExceeded)", err)
+	}
+	if !strings.Contains(err.Error(), d.String()) {
+		t.Errorf("err = %q; want it to mention %s", err, d)
+	}
+	if got != "" {
+		t.Errorf("got %q on timeout; want zero value", got)
+	}
+}
+
+// TestDoWithTimeoutParentCancelled checks that cancellation coming from the
+// parent is passed through instead of being reported as our timeout.
+func TestDoWithTimeoutParentCancelled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := DoWithTimeout(parent, time.Second,
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v; want context.Canceled", err)
+	}
+}
@@ -13,6 +13,7 @@ type ctxKey string
 const (
 	keyRequestID ctxKey = "requestID"
 	keyUserID    ctxKey = "userID"
+	keyTraceID   ctxKey = "traceID"
 )
 
 // demoValue shows how to thread request-scoped data down a call chain.
@@ -39,9 +40,30 @@ func handleRequest(ctx context.Context) {
 
 	// Pass the same ctx down; no need to re-attach the values.
 	processRequest(ctx)
+
+	// Debugging: which of the keys we care about actually reached here?
+	fmt.Printf("DumpValues     → %v (traceID was never set)\n",
+		DumpValues(ctx, keyRequestID, keyUserID, keyTraceID))
 }
 
 func processRequest(ctx context.Context) {
 	reqID := ctx.Value(keyRequestID).(string)
 	fmt.Printf("processRequest → reqID=%s (value flows transparently)\n", reqID)
 }
+
+// DumpValues returns the values ctx holds for the given keys, omitting keys
+// that are not set. It is a debugging aid for "did this value propagate down
+// to my handler?".
+//
+// context.Context does not expose its keys, so the caller must name them; each
+// one costs a ctx.Value lookup up the parent chain. A key explicitly set to
+// nil is indistinguishable from an absent one and is omitted.
+func DumpValues(ctx context.Context, keys ...any) map[any]any {
+	out := make(map[any]any, len(keys))
+	for _, k := range keys {
+		if v := ctx.Value(k); v != nil {
+			out[k] = v
+		}
+	}
+	return out
+}
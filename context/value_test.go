@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+// TestDumpValues sets a few values (one shadowed by a child context) and
+// checks that DumpValues returns exactly the present keys with their nearest
+// values, omitting absent ones.
+func TestDumpValues(t *testing.T) {
+	type otherKey string // same underlying string, different type → different key
+
+	ctx := context.WithValue(context.Background(), keyRequestID, "req-1")
+	ctx = context.WithValue(ctx, keyUserID, 7)
+	ctx = context.WithValue(ctx, keyUserID, 42) // child shadows parent
+	ctx, cancel := context.WithCancel(ctx)      // values survive non-value wrappers
+	defer cancel()
+
+	got := DumpValues(ctx, keyRequestID, keyUserID, keyTraceID, otherKey("requestID"))
+	want := map[any]any{keyRequestID: "req-1", keyUserID: 42}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestDumpValuesNoKeys checks the empty cases return an empty, non-nil map.
+func TestDumpValuesNoKeys(t *testing.T) {
+	for _, got := range []map[any]any{
+		DumpValues(context.Background()),
+		DumpValues(context.Background(), keyUserID),
+	} {
+		if got == nil || len(got) != 0 {
+			t.Errorf("got %v; want empty map", got)
+		}
+	}
+}
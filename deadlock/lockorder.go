@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// ── Fix for the AB deadlock: global lock ordering ────────────────────────────
+//
+// The AB deadlock in mutex.go happens because goroutine1 takes A→B while
+// goroutine2 takes B→A. If every goroutine acquires any set of locks in the
+// same global order, no cycle can form in the wait-for graph and the
+// deadlock is impossible.
+//
+// The mutex's address is a convenient global order, but only for mutexes that
+// live on the heap — fields of heap-allocated structs, package variables, or
+// locals that escape, like a and b in the demo, which goroutines share. Go's
+// GC does not move heap objects, and sync.Mutex must not be copied after use,
+// so such an address is unique and stable. A mutex on a goroutine's stack is
+// not: the stack is copied when it grows, so its address can change between
+// two calls and the order with it. A mutex shared between goroutines always
+// escapes, so in practice LockAll only sees heap addresses.
+
+// LockAll acquires every mutex in mus in ascending address order, regardless
+// of the order the caller passes them in, and returns a function that
+// releases them in reverse order.
+//
+// Duplicate pointers are locked only once (sync.Mutex is not reentrant, so
+// locking the same mutex twice would self-deadlock).
+//
+//	unlock := LockAll(&muB, &muA) // always locks muA/muB in the same order
+//	defer unlock()
+func LockAll(mus ...*sync.Mutex) (unlock func()) {
+	sorted := make([]*sync.Mutex, 0, len(mus))
+	seen := make(map[*sync.Mutex]bool, len(mus))
+	for _, mu := range mus {
+		if !seen[mu] {
+			seen[mu] = true
+			sorted = append(sorted, mu)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return uintptr(unsafe.Pointer(sorted[i])) < uintptr(unsafe.Pointer(sorted[j]))
+	})
+
+	for _, mu := range sorted {
+		mu.Lock()
+	}
+
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			sorted[i].Unlock()
+		}
+	}
+}
+
+// demoLockOrdering runs the same A→B / B→A scenario as demoMutexDeadlock, but
+// both goroutines go through LockAll, so they never deadlock.
+func demoLockOrdering() {
+	var a, b sync.Mutex
+	var wg sync.WaitGroup
+
+	worker := func(name string, first, second *sync.Mutex) {
+		defer wg.Done()
+		for i := 0; i < 3; i++ {
+			unlock := LockAll(first, second)
+			time.Sleep(5 * time.Millisecond) // hold both, like the AB demo
+			unlock()
+		}
+		fmt.Printf("  %s: locked both 3 times\n", name)
+	}
+
+	wg.Add(2)
+	go worker("goroutine1 (asks A, B)", &a, &b)
+	go worker("goroutine2 (asks B, A)", &b, &a)
+	wg.Wait()
+
+	fmt.Println("  no deadlock: LockAll imposed a single global order")
+}
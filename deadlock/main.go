@@ -29,9 +29,13 @@ func main() {
 	section("[semacquire] / [sync.Mutex.Lock] — blocked waiting to acquire a mutex")
 	demoSemacquire()
 
+	section("Fix: LockAll — global lock ordering by address")
+	demoLockOrdering()
+
 	section("[semacquire]   — AB deadlock: inconsistent lock ordering")
 	fmt.Println("  Shows complete dump with all accumulated states, then exits with code 1.")
-	fmt.Println("  On a net-free program the runtime itself would print the fatal error.\n")
+	fmt.Println("  On a net-free program the runtime itself would print the fatal error.")
+	fmt.Println()
 	demoMutexDeadlock()
 }
 
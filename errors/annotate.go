@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ── Patrón: Annotate — cadena de operaciones + request ID del contexto ───────
+
+// requestIDKey is the unexported context key for WithRequestID; nobody outside
+// this file can collide with it.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying id, for Annotate to pick up.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the ID stored by WithRequestID, or "" if there is none.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// AnnotatedError records one layer an error passed through on its way up:
+// the operation that failed and the request it failed for.
+type AnnotatedError struct {
+	Op        string // "repo.FindUser", "service.GetUser", …
+	RequestID string // from the context; "" if there was none
+	Err       error  // underlying cause
+}
+
+// Error renders consecutive annotations as one chain, outermost first, with
+// the request ID shown once up front:
+//
+//	[req=7f3a] handler.GetUser: service.GetUser: repo.FindUser: not found
+func (e *AnnotatedError) Error() string {
+	var ops []string
+	var id string
+	var cause error = e
+	for {
+		ae, ok := cause.(*AnnotatedError)
+		if !ok {
+			break
+		}
+		ops = append(ops, ae.Op)
+		if id == "" {
+			id = ae.RequestID
+		}
+		cause = ae.Err
+	}
+
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "[req=%s] ", id)
+	}
+	b.WriteString(strings.Join(ops, ": "))
+	if cause != nil {
+		b.WriteString(": ")
+		b.WriteString(cause.Error())
+	}
+	return b.String()
+}
+
+// Unwrap exposes the underlying error to errors.Is and errors.As.
+func (e *AnnotatedError) Unwrap() error { return e.Err }
+
+// Annotate wraps err with op and the request ID in ctx, if any. Calling it at
+// each layer builds a readable operation chain while errors.Is/As still reach
+// the root cause. Annotate(ctx, nil, op) returns nil, so it can wrap a return
+// value unconditionally.
+func Annotate(ctx context.Context, err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	return &AnnotatedError{Op: op, RequestID: RequestIDFrom(ctx), Err: err}
+}
+
+// Three layers that each annotate on the way up.
+func repoFindUser(ctx context.Context, id int) error {
+	return Annotate(ctx, ErrNotFound, fmt.Sprintf("repo.FindUser id=%d", id))
+}
+
+func serviceGetUser(ctx context.Context, id int) error {
+	return Annotate(ctx, repoFindUser(ctx, id), "service.GetUser")
+}
+
+func handlerGetUser(ctx context.Context, id int) error {
+	return Annotate(ctx, serviceGetUser(ctx, id), "handler.GetUser")
+}
+
+func demoAnnotate() {
+	ctx := WithRequestID(context.Background(), "7f3a")
+	err := handlerGetUser(ctx, 42)
+
+	fmt.Println("  error:", err)
+	fmt.Println("  Is(ErrNotFound):", errors.Is(err, ErrNotFound))
+
+	var ae *AnnotatedError
+	if errors.As(err, &ae) {
+		fmt.Printf("  outermost: op=%q request=%q\n", ae.Op, ae.RequestID)
+	}
+
+	fmt.Println("  no request ID:", handlerGetUser(context.Background(), 7))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestAnnotateBuildsOpChain wraps a sentinel through three Annotate calls and
+// checks the message shows the request ID once followed by the op chain, and
+// that errors.Is and errors.As still see through every layer.
+func TestAnnotateBuildsOpChain(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	err := Annotate(ctx, ErrNotFound, "repo")
+	err = Annotate(ctx, err, "service")
+	err = Annotate(ctx, err, "handler")
+
+	if got, want := err.Error(), "[req=req-1] handler: service: repo: not found"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false; want true")
+	}
+	var ae *AnnotatedError
+	if !errors.As(err, &ae) || ae.Op != "handler" {
+		t.Errorf("errors.As outermost Op = %v; want %q", ae, "handler")
+	}
+}
+
+// TestAnnotateWithoutRequestID checks the chain renders without a request
+// prefix when the context carries none, and that a nil error stays nil.
+func TestAnnotateWithoutRequestID(t *testing.T) {
+	ctx := context.Background()
+
+	if err := Annotate(ctx, nil, "repo"); err != nil {
+		t.Errorf("Annotate(nil) = %v; want <nil>", err)
+	}
+
+	err := Annotate(ctx, Annotate(ctx, ErrTimeout, "dial"), "fetch")
+	if got, want := err.Error(), "fetch: dial: operation timed out"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+}
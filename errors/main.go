@@ -21,9 +21,18 @@ func main() {
 	section("errors.Join — múltiples errores (Go 1.20+)")
 	demoJoin()
 
+	section("Patrón: Validator[T] — reglas componibles")
+	demoValidator()
+
+	section("Patrón: MultiWriter — seguir escribiendo y unir errores")
+	demoMultiWriter()
+
 	section("Patrón: error de operación con contexto")
 	demoOpError()
 
+	section("Patrón: Annotate — cadena de operaciones + request ID")
+	demoAnnotate()
+
 	section("Patrón: errores opacos vs exportados")
 	demoOpaque()
 
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ── Patrón: MultiWriter que no se detiene ante el primer error ───────────────
+
+// MultiWriter returns a writer that duplicates each write to all of ws.
+//
+// Unlike io.MultiWriter, a failing writer does not stop the others: every
+// writer gets every write, and the failures come back together through
+// errors.Join, each wrapped with the writer's position. Logging to stdout and
+// a file keeps working on stdout when the disk fills up.
+func MultiWriter(ws ...io.Writer) io.Writer {
+	return &multiWriter{ws: append([]io.Writer(nil), ws...)}
+}
+
+type multiWriter struct {
+	ws []io.Writer
+}
+
+// Write returns len(p) if every writer accepted all of p. Otherwise n is the
+// fewest bytes any writer accepted and err joins every failure; a short write
+// without an error counts as io.ErrShortWrite.
+func (m *multiWriter) Write(p []byte) (n int, err error) {
+	n = len(p)
+	var errs []error
+	for i, w := range m.ws {
+		wn, werr := w.Write(p)
+		if werr == nil && wn < len(p) {
+			werr = io.ErrShortWrite
+		}
+		if werr != nil {
+			errs = append(errs, fmt.Errorf("writer %d: %w", i, werr))
+		}
+		n = min(n, wn)
+	}
+	return n, errors.Join(errs...)
+}
+
+// errDiskFull is the failure of the broken sink in demoMultiWriter.
+var errDiskFull = errors.New("disk full")
+
+type failingWriter struct{ err error }
+
+func (f failingWriter) Write(p []byte) (int, error) { return 0, f.err }
+
+func demoMultiWriter() {
+	var stdout, file bytes.Buffer
+	w := MultiWriter(&stdout, failingWriter{errDiskFull}, &file)
+
+	_, err := fmt.Fprint(w, "request served")
+	fmt.Printf("  stdout=%q file=%q\n", stdout.String(), file.String())
+	fmt.Println("  err:", err)
+	fmt.Println("  Is(errDiskFull):", errors.Is(err, errDiskFull))
+}
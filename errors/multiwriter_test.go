@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestMultiWriterContinuesPastFailure checks that a failing writer in the
+// middle does not keep the bytes from the writers after it, and that the
+// returned error wraps the failure.
+func TestMultiWriterContinuesPastFailure(t *testing.T) {
+	var before, after bytes.Buffer
+	w := MultiWriter(&before, failingWriter{errDiskFull}, &after)
+
+	n, err := w.Write([]byte("hello"))
+
+	if got := before.String(); got != "hello" {
+		t.Errorf("first writer got %q; want %q", got, "hello")
+	}
+	if got := after.String(); got != "hello" {
+		t.Errorf("last writer got %q; want %q", got, "hello")
+	}
+	if !errors.Is(err, errDiskFull) {
+		t.Errorf("err = %v; want it to wrap errDiskFull", err)
+	}
+	if n != 0 {
+		t.Errorf("n = %d; want 0 (the failing writer accepted nothing)", n)
+	}
+}
+
+// TestMultiWriterAllSucceed checks that without failures Write reports the
+// full length and a nil error.
+func TestMultiWriterAllSucceed(t *testing.T) {
+	var a, b bytes.Buffer
+	n, err := MultiWriter(&a, &b).Write([]byte("hi"))
+	if n != 2 || err != nil {
+		t.Errorf("Write = %d, %v; want 2, <nil>", n, err)
+	}
+}
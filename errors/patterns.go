@@ -53,6 +53,10 @@ var ErrInvalidInput = errors.New("invalid input")
 // Use this when the error is an internal implementation detail.
 var opaqueErr = errors.New("internal state corrupted")
 
+// process validates input. The empty input is the caller's mistake and is
+// reported with the exported ErrInvalidInput; a corrupt input is an internal
+// failure, reported as text only: the caller can log it but not branch on it,
+// so opaqueErr can change without breaking anyone.
 func process(input string) error {
 	if input == "" {
 		// Exported: callers are expected to handle this case.
@@ -66,6 +70,17 @@ func process(input string) error {
 	return nil
 }
 
+// processBatch runs process on every input and joins the failures with
+// errors.Join. The joined error matches ErrInvalidInput if any input was
+// empty; the opaque failures stay opaque inside it.
+func processBatch(inputs []string) error {
+	var errs []error
+	for _, in := range inputs {
+		errs = append(errs, process(in)) // errors.Join drops the nils
+	}
+	return errors.Join(errs...)
+}
+
 func demoOpaque() {
 	cases := []string{"", "corrupt", "ok"}
 	for _, input := range cases {
@@ -78,6 +93,11 @@ func demoOpaque() {
 		fmt.Printf("    Is(ErrInvalidInput): %v\n", errors.Is(err, ErrInvalidInput))
 	}
 	// Output shows that only the exported sentinel is detectable by callers.
+
+	err := processBatch([]string{"corrupt", "", "ok"})
+	fmt.Printf("  processBatch → %q\n", err)
+	fmt.Printf("    Is(ErrInvalidInput): %v (from the empty input; the corrupt one stays opaque)\n",
+		errors.Is(err, ErrInvalidInput))
 }
 
 // ── Patrón: panic vs error ───────────────────────────────────────────────────
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestProcessOpaqueVsExported checks that the empty-input error matches the
+// exported ErrInvalidInput, while the corrupt-input error matches neither it
+// nor the internal opaqueErr: it is wrapped with %v on purpose, so callers
+// can read the message but cannot depend on the internal error.
+func TestProcessOpaqueVsExported(t *testing.T) {
+	if err := process(""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("process(\"\") = %v; want it to match ErrInvalidInput", err)
+	}
+
+	err := process("corrupt")
+	if err == nil {
+		t.Fatal("process(\"corrupt\") = nil; want an error")
+	}
+	if errors.Is(err, ErrInvalidInput) || errors.Is(err, opaqueErr) {
+		t.Errorf("process(\"corrupt\") = %v matches a sentinel; want it opaque", err)
+	}
+
+	if err := process("ok"); err != nil {
+		t.Errorf("process(\"ok\") = %v; want nil", err)
+	}
+}
+
+// TestProcessBatchJoin checks that errors.Join of an opaque and an exported
+// error matches only the exported one, and that a batch without failures
+// returns nil.
+func TestProcessBatchJoin(t *testing.T) {
+	err := processBatch([]string{"corrupt", "", "ok"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("processBatch = %v; want it to match ErrInvalidInput", err)
+	}
+	if errors.Is(err, opaqueErr) {
+		t.Errorf("processBatch = %v matches opaqueErr; want the opaque error to stay opaque", err)
+	}
+	if got := len(err.(interface{ Unwrap() []error }).Unwrap()); got != 2 {
+		t.Errorf("joined %d errors; want 2 (nil results dropped)", got)
+	}
+
+	if err := processBatch([]string{"ok", "fine"}); err != nil {
+		t.Errorf("processBatch of valid inputs = %v; want nil", err)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ── Patrón: validación declarativa con reglas componibles ────────────────────
+
+// Validator runs a list of named rules against a value of type T. A handler
+// declares the rules once and validates every decoded request with them:
+//
+//	var validateUser = new(Validator[User]).
+//		Rule("name", func(u User) error { ... }).
+//		Rule("email", func(u User) error { ... })
+//
+// The zero value is ready to use and accepts everything.
+type Validator[T any] struct {
+	rules []rule[T]
+}
+
+type rule[T any] struct {
+	name  string
+	check func(T) error
+}
+
+// Rule adds a check reported under name (usually the field it inspects) and
+// returns v so calls can be chained. Rules run in the order they were added.
+func (v *Validator[T]) Rule(name string, check func(T) error) *Validator[T] {
+	v.rules = append(v.rules, rule[T]{name: name, check: check})
+	return v
+}
+
+// Validate runs every rule — it does not stop at the first failure — and
+// returns nil if all pass. Otherwise it returns ValidationErrors with one
+// *ValidationError per failed rule, in rule order.
+func (v *Validator[T]) Validate(x T) error {
+	var errs ValidationErrors
+	for _, r := range v.rules {
+		if err := r.check(x); err != nil {
+			errs = append(errs, &ValidationError{Field: r.name, Message: err.Error()})
+		}
+	}
+	if len(errs) == 0 {
+		return nil // an untyped nil: a nil ValidationErrors would be a non-nil error
+	}
+	return errs
+}
+
+// ValidationErrors aggregates the failures of one Validate call.
+type ValidationErrors []*ValidationError
+
+// Error lists every failure, one per line, in rule order.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ve := range e {
+		msgs[i] = ve.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap exposes each *ValidationError to errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ve := range e {
+		errs[i] = ve
+	}
+	return errs
+}
+
+type signupRequest struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+var validateSignup = new(Validator[signupRequest]).
+	Rule("name", func(r signupRequest) error {
+		if r.Name == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}).
+	Rule("email", func(r signupRequest) error {
+		if !strings.Contains(r.Email, "@") {
+			return fmt.Errorf("%q is not an email address", r.Email)
+		}
+		return nil
+	}).
+	Rule("age", func(r signupRequest) error {
+		if r.Age < 18 {
+			return fmt.Errorf("must be at least 18, got %d", r.Age)
+		}
+		return nil
+	})
+
+func demoValidator() {
+	ok := signupRequest{Name: "ana", Email: "ana@example.com", Age: 30}
+	fmt.Println("  valid request →", validateSignup.Validate(ok))
+
+	bad := signupRequest{Name: "", Email: "ana.example.com", Age: 30}
+	err := validateSignup.Validate(bad)
+	fmt.Println("  invalid request →")
+	fmt.Println(" ", strings.ReplaceAll(err.Error(), "\n", "\n  "))
+
+	// The aggregate can be ranged over (e.g. to build a JSON error body)…
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		for _, ve := range verrs {
+			fmt.Printf("    field=%-6s msg=%s\n", ve.Field, ve.Message)
+		}
+	}
+	// …and errors.As still finds the individual *ValidationError.
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		fmt.Printf("  first failure: field=%q\n", ve.Field)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+type account struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func newAccountValidator() *Validator[account] {
+	return new(Validator[account]).
+		Rule("name", func(a account) error {
+			if a.Name == "" {
+				return errors.New("must not be empty")
+			}
+			return nil
+		}).
+		Rule("email", func(a account) error {
+			if a.Email == "" {
+				return errors.New("is required")
+			}
+			return nil
+		}).
+		Rule("age", func(a account) error {
+			if a.Age < 0 {
+				return errors.New("must not be negative")
+			}
+			return nil
+		})
+}
+
+// TestValidatorAggregatesFailures checks that with two of three rules
+// failing, Validate reports both, in rule order, with a deterministic message.
+func TestValidatorAggregatesFailures(t *testing.T) {
+	err := newAccountValidator().Validate(account{Name: "", Email: "", Age: 30})
+
+	want := "validation error on \"name\": must not be empty\n" +
+		"validation error on \"email\": is required"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got %q; want %q", err, want)
+	}
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("errors.As(ValidationErrors) failed for %T", err)
+	}
+	if len(verrs) != 2 || verrs[0].Field != "name" || verrs[1].Field != "email" {
+		t.Errorf("got fields %v; want [name email]", fields(verrs))
+	}
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Field != "name" {
+		t.Errorf("errors.As(*ValidationError) = %v; want the name failure", ve)
+	}
+}
+
+// TestValidatorValid checks that Validate returns a nil error (not a typed
+// nil) when every rule passes, and that the zero Validator accepts anything.
+func TestValidatorValid(t *testing.T) {
+	if err := newAccountValidator().Validate(account{Name: "ana", Email: "a@b.c"}); err != nil {
+		t.Errorf("got %v; want <nil>", err)
+	}
+	var zero Validator[account]
+	if err := zero.Validate(account{}); err != nil {
+		t.Errorf("zero Validator: got %v; want <nil>", err)
+	}
+}
+
+func fields(errs ValidationErrors) []string {
+	out := make([]string, len(errs))
+	for i, e := range errs {
+		out[i] = e.Field
+	}
+	return out
+}
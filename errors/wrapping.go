@@ -42,5 +42,5 @@ func demoWrapping() {
 	// Using %v hides the cause: errors.Is cannot find it.
 	opaque := fmt.Errorf("something went wrong: %v", dbErr) // %v, not %w
 	fmt.Println("\n  opaque error:", opaque)
-	fmt.Println("  Is(dbErr) through %%v:", errors.Is(opaque, dbErr)) // false — chain is broken
+	fmt.Printf("  Is(dbErr) through %%v: %v\n", errors.Is(opaque, dbErr)) // false — chain is broken
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// ── Bag[T comparable] — multiset / frequency counter ─────────────────────────
+// Set[T] answers "is x there?"; Bag[T] answers "how many x are there?". It is
+// a map[T]int with the bookkeeping done once: removing the last occurrence
+// deletes the key, and MostCommon ranks values by frequency.
+
+// Bag is a multiset: it counts how many times each value was added.
+type Bag[T comparable] struct {
+	m     map[T]bagEntry
+	total int
+	seq   int // next first-seen sequence number
+}
+
+type bagEntry struct {
+	count int
+	seq   int // order of first appearance; breaks MostCommon ties
+}
+
+func NewBag[T comparable](vals ...T) *Bag[T] {
+	b := &Bag[T]{m: make(map[T]bagEntry)}
+	for _, v := range vals {
+		b.Add(v)
+	}
+	return b
+}
+
+func (b *Bag[T]) Add(v T) { b.AddN(v, 1) }
+
+// AddN adds n occurrences of v. n <= 0 is a no-op.
+func (b *Bag[T]) AddN(v T, n int) {
+	if n <= 0 {
+		return
+	}
+	e, ok := b.m[v]
+	if !ok {
+		e.seq = b.seq
+		b.seq++
+	}
+	e.count += n
+	b.m[v] = e
+	b.total += n
+}
+
+// Remove removes one occurrence of v; removing the last one deletes v from
+// the bag. Removing a value that isn't there is a no-op.
+func (b *Bag[T]) Remove(v T) {
+	e, ok := b.m[v]
+	if !ok {
+		return
+	}
+	b.total--
+	if e.count == 1 {
+		delete(b.m, v)
+		return
+	}
+	e.count--
+	b.m[v] = e
+}
+
+func (b *Bag[T]) Count(v T) int { return b.m[v].count }
+func (b *Bag[T]) Len() int      { return b.total } // occurrences, not distinct values
+
+// Distinct returns each value once, in order of first appearance.
+func (b *Bag[T]) Distinct() []T {
+	out := make([]T, 0, len(b.m))
+	for _, p := range b.sorted(func(x, y bagEntry) int { return cmp.Compare(x.seq, y.seq) }) {
+		out = append(out, p.First)
+	}
+	return out
+}
+
+// MostCommon returns the k most frequent values with their counts, highest
+// count first. Ties go to the value seen first, so the result is
+// deterministic. k <= 0 or k > number of distinct values returns them all.
+func (b *Bag[T]) MostCommon(k int) []Pair[T, int] {
+	ranked := b.sorted(func(x, y bagEntry) int {
+		if c := cmp.Compare(y.count, x.count); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.seq, y.seq)
+	})
+	if k > 0 && k < len(ranked) {
+		ranked = ranked[:k]
+	}
+	return ranked
+}
+
+// sorted returns every (value, count) pair, ordered by order over the entries.
+func (b *Bag[T]) sorted(order func(x, y bagEntry) int) []Pair[T, int] {
+	type item struct {
+		v T
+		e bagEntry
+	}
+	items := make([]item, 0, len(b.m))
+	for v, e := range b.m {
+		items = append(items, item{v, e})
+	}
+	slices.SortFunc(items, func(x, y item) int { return order(x.e, y.e) })
+
+	out := make([]Pair[T, int], len(items))
+	for i, it := range items {
+		out[i] = NewPair(it.v, it.e.count)
+	}
+	return out
+}
+
+func demoBag() {
+	words := []string{"go", "rust", "go", "zig", "go", "rust", "c", "zig", "go"}
+	b := NewBag(words...)
+
+	fmt.Println("  input         =", words)
+	fmt.Println("  Len           =", b.Len())
+	fmt.Println("  Distinct      =", b.Distinct())
+	fmt.Println("  Count(go)     =", b.Count("go"))
+	fmt.Println("  MostCommon(2) =", b.MostCommon(2))
+
+	b.Remove("c")
+	b.AddN("zig", 3)
+	fmt.Println("  after Remove(c), AddN(zig, 3):")
+	fmt.Println("  MostCommon(0) =", b.MostCommon(0))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestBagCounts adds a stream of values and checks Count, Len and Distinct,
+// including that removing the last occurrence drops the value entirely.
+func TestBagCounts(t *testing.T) {
+	b := NewBag[string]()
+	for _, v := range []string{"a", "b", "a", "c", "a", "b"} {
+		b.Add(v)
+	}
+	b.AddN("d", 4)
+	b.AddN("e", 0) // no-op
+
+	for v, want := range map[string]int{"a": 3, "b": 2, "c": 1, "d": 4, "e": 0, "zz": 0} {
+		if got := b.Count(v); got != want {
+			t.Errorf("Count(%q) = %d; want %d", v, got, want)
+		}
+	}
+	if got := b.Len(); got != 10 {
+		t.Errorf("Len() = %d; want 10", got)
+	}
+	if got, want := b.Distinct(), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Distinct() = %v; want %v", got, want)
+	}
+
+	b.Remove("c")
+	b.Remove("a")
+	b.Remove("missing") // no-op
+	if got := b.Count("c"); got != 0 {
+		t.Errorf("Count(c) after Remove = %d; want 0", got)
+	}
+	if got := b.Count("a"); got != 2 {
+		t.Errorf("Count(a) after Remove = %d; want 2", got)
+	}
+	if got, want := b.Distinct(), []string{"a", "b", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Distinct() after Remove = %v; want %v", got, want)
+	}
+	if got := b.Len(); got != 8 {
+		t.Errorf("Len() after Remove = %d; want 8", got)
+	}
+}
+
+// TestBagMostCommon checks MostCommon returns the top-k in descending
+// frequency order, breaking ties by first appearance.
+func TestBagMostCommon(t *testing.T) {
+	b := NewBag(3, 1, 2, 1, 3, 1, 4, 2, 5)
+	// counts: 1→3, 3→2, 2→2, 4→1, 5→1 (3 seen before 2, 4 before 5)
+
+	tests := []struct {
+		k    int
+		want []Pair[int, int]
+	}{
+		{1, []Pair[int, int]{{1, 3}}},
+		{3, []Pair[int, int]{{1, 3}, {3, 2}, {2, 2}}},
+		{0, []Pair[int, int]{{1, 3}, {3, 2}, {2, 2}, {4, 1}, {5, 1}}},
+		{99, []Pair[int, int]{{1, 3}, {3, 2}, {2, 2}, {4, 1}, {5, 1}}},
+	}
+	for _, tt := range tests {
+		if got := b.MostCommon(tt.k); !slices.Equal(got, tt.want) {
+			t.Errorf("MostCommon(%d) = %v; want %v", tt.k, got, tt.want)
+		}
+	}
+}
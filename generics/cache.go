@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── Cache[K, V] — Memoize + singleflight + TTL ───────────────────────────────
+// Memoize caches forever and lets concurrent misses all call f; TTLSet
+// expires but stores no values. Cache combines the three ideas: successful
+// loads live for ttl, concurrent misses on one key share a single load, and
+// errors are never cached, so the next call retries.
+
+// Cache is a TTL cache with per-key load deduplication. It is safe for
+// concurrent use.
+type Cache[K comparable, V any] struct {
+	ttl time.Duration
+	now func() time.Time // overridable in tests
+
+	mu        sync.Mutex
+	entries   map[K]cacheEntry[V]
+	loads     map[K]*load[V] // in-progress loads; guarded by mu
+	nextSweep time.Time
+}
+
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// load is one in-progress loader call shared by every caller of the same key.
+type load[V any] struct {
+	done  chan struct{} // closed once value and err are set
+	value V
+	err   error
+}
+
+// NewCache returns an empty cache whose entries live for ttl.
+func NewCache[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[K]cacheEntry[V]),
+		loads:   make(map[K]*load[V]),
+	}
+}
+
+// GetOrLoad returns the cached value for k if it has not expired. Otherwise
+// it calls loader — or joins the call already running for k — and caches the
+// result for ttl if loader succeeded.
+//
+// loader runs detached from ctx (context.WithoutCancel), because its result
+// is shared with the other callers; ctx only bounds how long this caller
+// waits. A caller that gives up does not cancel the load.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, k K, loader func(ctx context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	now := c.now()
+	c.sweepLocked(now)
+
+	if e, ok := c.entries[k]; ok && now.Before(e.expiresAt) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	l, running := c.loads[k]
+	if !running {
+		l = &load[V]{done: make(chan struct{})}
+		c.loads[k] = l
+		go c.run(context.WithoutCancel(ctx), k, l, loader)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-l.done:
+		return l.value, l.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// run calls loader for k, stores a successful result and wakes the waiters.
+func (c *Cache[K, V]) run(ctx context.Context, k K, l *load[V], loader func(context.Context) (V, error)) {
+	v, err := loader(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.entries[k] = cacheEntry[V]{value: v, expiresAt: c.now().Add(c.ttl)}
+	}
+	delete(c.loads, k)
+	c.mu.Unlock()
+
+	l.value, l.err = v, err
+	close(l.done)
+}
+
+// Len returns the number of cached entries, including any that expired but
+// have not been swept yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// sweepLocked drops expired entries, at most once per ttl (see
+// TTLSet.sweepLocked). c.mu must be held.
+func (c *Cache[K, V]) sweepLocked(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
+
+func demoCache() {
+	cache := NewCache[int, string](50 * time.Millisecond)
+
+	var calls atomic.Int32
+	loadUser := func(id int) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			calls.Add(1)
+			time.Sleep(20 * time.Millisecond) // slow backend
+			if id < 0 {
+				return "", errors.New("invalid id")
+			}
+			return fmt.Sprintf("user-%d", id), nil
+		}
+	}
+
+	// Ten concurrent requests for the same user: one load.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.GetOrLoad(context.Background(), 42, loadUser(42))
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("  10 concurrent GetOrLoad(42) → loader calls: %d\n", calls.Load())
+
+	v, _ := cache.GetOrLoad(context.Background(), 42, loadUser(42))
+	fmt.Printf("  cached: %s, loader calls: %d\n", v, calls.Load())
+
+	time.Sleep(60 * time.Millisecond) // past the ttl
+	cache.GetOrLoad(context.Background(), 42, loadUser(42))
+	fmt.Printf("  after ttl → loader calls: %d\n", calls.Load())
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.GetOrLoad(context.Background(), -1, loadUser(-1))
+		fmt.Printf("  GetOrLoad(-1) → %v (errors aren't cached), loader calls: %d\n", err, calls.Load())
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestCache(ttl time.Duration) (*Cache[string, int], *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	c := NewCache[string, int](ttl)
+	c.now = clock.now
+	return c, clock
+}
+
+// TestCacheLoadsOnceConcurrently starts many GetOrLoad calls for one key
+// while the loader is blocked and checks that it ran once and every caller
+// got its value.
+func TestCacheLoadsOnceConcurrently(t *testing.T) {
+	c := NewCache[string, int](time.Minute)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 7, nil
+	}
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "k", loader)
+			if err != nil {
+				t.Errorf("GetOrLoad: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond) // let the callers pile up on the load
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader calls = %d; want 1", n)
+	}
+	for i, v := range results {
+		if v != 7 {
+			t.Errorf("caller %d got %d; want 7", i, v)
+		}
+	}
+}
+
+// TestCacheExpiresAfterTTL checks that a value is served from the cache
+// until ttl has passed and reloaded after.
+func TestCacheExpiresAfterTTL(t *testing.T) {
+	c, clock := newTestCache(time.Minute)
+
+	var calls int
+	loader := func(ctx context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+	get := func() int {
+		t.Helper()
+		v, err := c.GetOrLoad(context.Background(), "k", loader)
+		if err != nil {
+			t.Fatalf("GetOrLoad: %v", err)
+		}
+		return v
+	}
+
+	if got := get(); got != 1 {
+		t.Errorf("first get = %d; want 1", got)
+	}
+	clock.advance(59 * time.Second)
+	if got := get(); got != 1 {
+		t.Errorf("get within ttl = %d; want 1 (cached)", got)
+	}
+	clock.advance(time.Second)
+	if got := get(); got != 2 {
+		t.Errorf("get after ttl = %d; want 2 (reloaded)", got)
+	}
+}
+
+// TestCacheDoesNotCacheErrors checks that a failed load is returned to the
+// caller but the next call runs the loader again.
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+	errDown := errors.New("backend down")
+
+	var calls int
+	loader := func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errDown
+		}
+		return 5, nil
+	}
+
+	if _, err := c.GetOrLoad(context.Background(), "k", loader); !errors.Is(err, errDown) {
+		t.Fatalf("first GetOrLoad err = %v; want errDown", err)
+	}
+	v, err := c.GetOrLoad(context.Background(), "k", loader)
+	if err != nil || v != 5 {
+		t.Errorf("second GetOrLoad = %d, %v; want 5, <nil>", v, err)
+	}
+	if calls != 2 {
+		t.Errorf("loader calls = %d; want 2", calls)
+	}
+}
+
+// TestCacheCallerCancel checks that a caller whose ctx ends stops waiting
+// with ctx.Err() while the load still completes for everyone else.
+func TestCacheCallerCancel(t *testing.T) {
+	c := NewCache[string, int](time.Minute)
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (int, error) {
+		<-release
+		return 9, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetOrLoad(ctx, "k", loader); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled GetOrLoad err = %v; want context.Canceled", err)
+	}
+
+	close(release)
+	if v, err := c.GetOrLoad(context.Background(), "k", loader); err != nil || v != 9 {
+		t.Errorf("GetOrLoad after cancel = %d, %v; want 9, <nil>", v, err)
+	}
+}
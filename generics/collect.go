@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// ── Channel aggregation — Pair[K, V] stream → map[K]V ────────────────────────
+// Fan-out workers often report results as (id, value) pairs on a shared
+// channel. CollectByKey drains that channel into a map so the caller can look
+// results up by id instead of relying on arrival order.
+
+// CollectByKey drains in into a map keyed by Pair.First. If the same key
+// arrives more than once, the later value overwrites the earlier one.
+//
+// It returns when in is closed (err == nil) or when ctx is cancelled, in which
+// case the map holds everything received so far and err is ctx.Err().
+func CollectByKey[K comparable, V any](ctx context.Context, in <-chan Pair[K, V]) (map[K]V, error) {
+	out := make(map[K]V)
+	for {
+		select {
+		case p, ok := <-in:
+			if !ok {
+				return out, nil
+			}
+			out[p.First] = p.Second
+		case <-ctx.Done():
+			return out, ctx.Err()
+		}
+	}
+}
+
+// Fold is a streaming Reduce: it applies reduce to each value received from
+// in, left to right, starting from initial — an event-sourced state built by
+// replaying events.
+//
+// It returns the final accumulator when in is closed (err == nil), or the
+// accumulator built so far and ctx.Err() when ctx is cancelled.
+func Fold[T, A any](ctx context.Context, in <-chan T, initial A, reduce func(A, T) A) (A, error) {
+	acc := initial
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return acc, nil
+			}
+			acc = reduce(acc, v)
+		case <-ctx.Done():
+			return acc, ctx.Err()
+		}
+	}
+}
+
+// account is the state folded from a stream of deposits and withdrawals.
+type account struct {
+	Balance, Ops int
+}
+
+func demoCollect() {
+	in := make(chan Pair[string, int])
+	go func() {
+		defer close(in)
+		for _, w := range []string{"go", "rust", "zig", "go"} {
+			in <- NewPair(w, len(w)*10) // "go" is sent twice: last write wins
+		}
+	}()
+
+	m, err := CollectByKey(context.Background(), in)
+	fmt.Printf("  CollectByKey → %v (err=%v)\n", m, err)
+
+	events := make(chan int)
+	go func() {
+		defer close(events)
+		for _, amount := range []int{100, -30, 50, -20} {
+			events <- amount
+		}
+	}()
+
+	acc, err := Fold(context.Background(), events, account{}, func(a account, amount int) account {
+		return account{Balance: a.Balance + amount, Ops: a.Ops + 1}
+	})
+	fmt.Printf("  Fold         → %+v (err=%v)\n", acc, err)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+)
+
+// TestCollectByKey drains a producer of keyed pairs and checks the final map,
+// including that a repeated key keeps the last value.
+func TestCollectByKey(t *testing.T) {
+	in := make(chan Pair[int, string])
+	go func() {
+		defer close(in)
+		in <- NewPair(1, "one")
+		in <- NewPair(2, "two")
+		in <- NewPair(3, "three")
+		in <- NewPair(1, "uno") // overwrites "one"
+	}()
+
+	got, err := CollectByKey(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[int]string{1: "uno", 2: "two", 3: "three"}
+	if !maps.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestCollectByKeyCancel checks that cancellation returns the partial map
+// collected so far together with ctx.Err().
+func TestCollectByKeyCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan Pair[string, int]) // never closed
+
+	go func() {
+		in <- NewPair("a", 1)
+		in <- NewPair("b", 2)
+		cancel() // both pairs were received before cancel
+	}()
+
+	got, err := CollectByKey(ctx, in)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v; want context.Canceled", err)
+	}
+	if want := map[string]int{"a": 1, "b": 2}; !maps.Equal(got, want) {
+		t.Errorf("partial map = %v; want %v", got, want)
+	}
+}
+
+// TestFoldSum folds a closed channel of ints into their sum.
+func TestFoldSum(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 10; i++ {
+			in <- i
+		}
+	}()
+
+	got, err := Fold(context.Background(), in, 0, func(sum, v int) int { return sum + v })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 55 {
+		t.Errorf("got %d; want 55", got)
+	}
+}
+
+// TestFoldCancel checks that cancellation returns the partial sum of the
+// values received so far together with ctx.Err().
+func TestFoldCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int) // never closed
+
+	go func() {
+		in <- 1
+		in <- 2
+		in <- 3
+		cancel() // all three values were received before cancel
+	}()
+
+	got, err := Fold(ctx, in, 0, func(sum, v int) int { return sum + v })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v; want context.Canceled", err)
+	}
+	if got != 6 {
+		t.Errorf("partial sum = %d; want 6", got)
+	}
+}
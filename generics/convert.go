@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ── ConvertInt — integer conversion that refuses to wrap ─────────────────────
+// A Go conversion between integer types never fails: uint8(300) is 44 and
+// uint(-1) is 18446744073709551615. ConvertInt does the same conversion and
+// then checks that nothing was lost.
+
+// Integer is every signed and unsigned integer type, including defined types
+// such as `type UserID int64`.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// ErrOverflow is wrapped by ConvertInt when the value does not fit.
+var ErrOverflow = errors.New("integer overflow")
+
+// ConvertInt converts v from T to U, or returns an error wrapping
+// ErrOverflow if v is outside U's range. The types read in the order of the
+// conversion, ConvertInt[int, uint8](n); U cannot be inferred from v, so
+// both are given.
+//
+// The check needs no per-type bounds: the conversion is exact if and only if
+// converting back yields v and the sign survived. The round trip catches
+// truncation (300 → uint8 44 → int 44 ≠ 300); the sign check catches
+// reinterpretation between same-width types (int8 -1 → uint8 255 → int8 -1
+// round-trips, but the sign flipped).
+func ConvertInt[T, U Integer](v T) (U, error) {
+	u := U(v)
+	if T(u) != v || (v < 0) != (u < 0) {
+		return 0, fmt.Errorf("convert %v (%T) to %T: %w", v, v, u, ErrOverflow)
+	}
+	return u, nil
+}
+
+func demoConvert() {
+	show := func(label string, v any, err error) {
+		if err != nil {
+			fmt.Printf("  %-36s → error: %v\n", label, err)
+			return
+		}
+		fmt.Printf("  %-36s → %v\n", label, v)
+	}
+
+	fmt.Println("  Plain conversion wraps silently:")
+	n, neg := 300, -1
+	fmt.Printf("  %-36s → %d\n", "uint8(300)", uint8(n))
+	fmt.Printf("  %-36s → %d\n", "uint(-1)", uint(neg))
+
+	fmt.Println("\n  ConvertInt checks the range:")
+	u8, err := ConvertInt[int, uint8](200)
+	show("ConvertInt[int, uint8](200)", u8, err)
+	u8, err = ConvertInt[int, uint8](300)
+	show("ConvertInt[int, uint8](300)", u8, err)
+	u, err := ConvertInt[int, uint](-1)
+	show("ConvertInt[int, uint](-1)", u, err)
+	i32, err := ConvertInt[uint64, int32](math.MaxUint64)
+	show("ConvertInt[uint64, int32](MaxUint64)", i32, err)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestConvertIntInRange checks values that fit, including each target type's
+// bounds, convert unchanged.
+func TestConvertIntInRange(t *testing.T) {
+	if got, err := ConvertInt[int, uint8](255); got != 255 || err != nil {
+		t.Errorf("ConvertInt[int, uint8](255) = %d, %v; want 255, <nil>", got, err)
+	}
+	if got, err := ConvertInt[int, int8](-128); got != -128 || err != nil {
+		t.Errorf("ConvertInt[int, int8](-128) = %d, %v; want -128, <nil>", got, err)
+	}
+	if got, err := ConvertInt[uint64, int64](math.MaxInt64); got != math.MaxInt64 || err != nil {
+		t.Errorf("ConvertInt[uint64, int64](MaxInt64) = %d, %v; want MaxInt64, <nil>", got, err)
+	}
+	if got, err := ConvertInt[int8, uint64](0); got != 0 || err != nil {
+		t.Errorf("ConvertInt[int8, uint64](0) = %d, %v; want 0, <nil>", got, err)
+	}
+
+	type UserID int64 // defined types satisfy ~int64
+	if got, err := ConvertInt[uint16, UserID](42); got != 42 || err != nil {
+		t.Errorf("ConvertInt[uint16, UserID](42) = %d, %v; want 42, <nil>", got, err)
+	}
+}
+
+// TestConvertIntOverflow checks out-of-range values return ErrOverflow and
+// the zero value instead of a wrapped result.
+func TestConvertIntOverflow(t *testing.T) {
+	check := func(name string, got int64, err error) {
+		t.Helper()
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s: err = %v; want ErrOverflow", name, err)
+		}
+		if got != 0 {
+			t.Errorf("%s: value = %d; want 0", name, got)
+		}
+	}
+
+	u8, err := ConvertInt[int, uint8](300)
+	check("300 → uint8", int64(u8), err)
+
+	u, err := ConvertInt[int, uint](-1)
+	check("-1 → uint", int64(u), err)
+
+	u8, err = ConvertInt[int8, uint8](-1) // same width: only the sign differs
+	check("int8(-1) → uint8", int64(u8), err)
+
+	i8, err := ConvertInt[uint8, int8](200)
+	check("uint8(200) → int8", int64(i8), err)
+
+	i64, err := ConvertInt[uint64, int64](math.MaxUint64)
+	check("MaxUint64 → int64", i64, err)
+
+	i16, err := ConvertInt[int, int16](-40000)
+	check("-40000 → int16", int64(i16), err)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ── EncodeCSV / DecodeCSV — struct tags → columns ────────────────────────────
+// Generics give a typed API ([]T in, []T out); reflection does the part the
+// type system can't: reading the `csv:"name"` tags and converting each cell.
+
+// CSVError reports a cell that could not be converted. Row counts data rows
+// from 1 (the header is not a row).
+type CSVError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e *CSVError) Error() string {
+	return fmt.Sprintf("csv: row %d, column %q: %v", e.Row, e.Column, e.Err)
+}
+
+func (e *CSVError) Unwrap() error { return e.Err }
+
+// csvField maps one column to one struct field.
+type csvField struct {
+	name  string
+	index int
+}
+
+// csvFields lists the columns of struct type t: every exported field, named
+// by its `csv` tag (or the field name if untagged); `csv:"-"` skips a field.
+func csvFields(t reflect.Type) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: %v is not a struct", t)
+	}
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("csv")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return nil, fmt.Errorf("csv: field %s has unsupported type %v", f.Name, f.Type)
+		}
+		fields = append(fields, csvField{name: name, index: i})
+	}
+	return fields, nil
+}
+
+// EncodeCSV writes a header row followed by one row per element of rows.
+func EncodeCSV[T any](w io.Writer, rows []T) error {
+	fields, err := csvFields(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(fields))
+	for i, f := range fields {
+		record[i] = f.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i, f := range fields {
+			record[i] = formatCell(v.Field(f.index))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// DecodeCSV reads a header row and converts every following row into a T.
+// Columns are matched to fields by name, so their order does not matter;
+// unknown columns are ignored and missing ones leave the zero value.
+func DecodeCSV[T any](r io.Reader) ([]T, error) {
+	fields, err := csvFields(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv: missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// cols[i] is the field filled from column i, or -1 if none.
+	cols := make([]int, len(header))
+	for i, name := range header {
+		cols[i] = -1
+		for _, f := range fields {
+			if f.name == name {
+				cols[i] = f.index
+			}
+		}
+	}
+
+	var out []T
+	for row := 1; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var t T
+		v := reflect.ValueOf(&t).Elem()
+		for i, cell := range record {
+			if cols[i] < 0 {
+				continue
+			}
+			if err := parseCell(v.Field(cols[i]), cell); err != nil {
+				return nil, &CSVError{Row: row, Column: header[i], Err: err}
+			}
+		}
+		out = append(out, t)
+	}
+}
+
+func formatCell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	default: // ints; csvFields rejected everything else
+		return strconv.FormatInt(v.Int(), 10)
+	}
+}
+
+func parseCell(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	default:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	}
+	return nil
+}
+
+func demoCSV() {
+	type product struct {
+		SKU    string  `csv:"sku"`
+		Price  float64 `csv:"price"`
+		Stock  int     `csv:"stock"`
+		Active bool    `csv:"active"`
+	}
+
+	var buf bytes.Buffer
+	_ = EncodeCSV(&buf, []product{
+		{SKU: "A-1", Price: 9.5, Stock: 3, Active: true},
+		{SKU: "B-2", Price: 20, Stock: 0},
+	})
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		fmt.Println("  " + line)
+	}
+
+	back, err := DecodeCSV[product](&buf)
+	fmt.Printf("  decoded: %+v err=%v\n", back, err)
+
+	_, err = DecodeCSV[product](strings.NewReader("sku,stock\nA-1,3\nB-2,lots\n"))
+	fmt.Printf("  bad cell: %v\n", err)
+}
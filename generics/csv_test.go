@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type csvRecord struct {
+	Name    string  `csv:"name"`
+	Age     int     `csv:"age"`
+	Score   float64 `csv:"score"`
+	Active  bool    `csv:"active"`
+	Ignored string  `csv:"-"`
+}
+
+// TestCSVRoundTrip checks that DecodeCSV(EncodeCSV(rows)) gives rows back,
+// including a cell that needs quoting, and that the header uses the tags.
+func TestCSVRoundTrip(t *testing.T) {
+	rows := []csvRecord{
+		{Name: "Ana", Age: 31, Score: 9.75, Active: true},
+		{Name: "Lee, Jr.", Age: 0, Score: -1.5},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeCSV(&buf, rows); err != nil {
+		t.Fatalf("EncodeCSV: %v", err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); header != "name,age,score,active" {
+		t.Errorf("header = %q; want %q", header, "name,age,score,active")
+	}
+
+	got, err := DecodeCSV[csvRecord](&buf)
+	if err != nil {
+		t.Fatalf("DecodeCSV: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("got %+v; want %+v", got, rows)
+	}
+}
+
+// TestDecodeCSVBadNumber checks that a malformed numeric cell is reported
+// with its row and column and still wraps the strconv error.
+func TestDecodeCSVBadNumber(t *testing.T) {
+	in := "name,age\nAna,31\nLee,thirty\n"
+
+	_, err := DecodeCSV[csvRecord](strings.NewReader(in))
+
+	var cerr *CSVError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("err = %v; want *CSVError", err)
+	}
+	if cerr.Row != 2 || cerr.Column != "age" {
+		t.Errorf("row, column = %d, %q; want 2, %q", cerr.Row, cerr.Column, "age")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("err = %v; want it to wrap strconv.ErrSyntax", err)
+	}
+}
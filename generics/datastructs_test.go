@@ -0,0 +1,223 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// ── Correctness ──────────────────────────────────────────────────────────────
+
+// TestStack drives a Stack through a sequence of operations and checks the
+// result of each step.
+func TestStack(t *testing.T) {
+	type op struct {
+		name   string
+		push   int // used when name == "push"
+		want   int
+		wantOK bool
+		len    int
+	}
+	tests := []struct {
+		name string
+		ops  []op
+	}{
+		{"pop empty", []op{
+			{name: "pop", want: 0, wantOK: false, len: 0},
+		}},
+		{"peek empty", []op{
+			{name: "peek", want: 0, wantOK: false, len: 0},
+		}},
+		{"LIFO order", []op{
+			{name: "push", push: 1, len: 1},
+			{name: "push", push: 2, len: 2},
+			{name: "push", push: 3, len: 3},
+			{name: "peek", want: 3, wantOK: true, len: 3},
+			{name: "pop", want: 3, wantOK: true, len: 2},
+			{name: "pop", want: 2, wantOK: true, len: 1},
+			{name: "pop", want: 1, wantOK: true, len: 0},
+			{name: "pop", want: 0, wantOK: false, len: 0},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var s Stack[int]
+			for i, o := range tc.ops {
+				var got int
+				var ok bool
+				switch o.name {
+				case "push":
+					s.Push(o.push)
+				case "pop":
+					got, ok = s.Pop()
+				case "peek":
+					got, ok = s.Peek()
+				}
+				if o.name != "push" && (got != o.want || ok != o.wantOK) {
+					t.Errorf("op %d %s = (%d, %v); want (%d, %v)", i, o.name, got, ok, o.want, o.wantOK)
+				}
+				if s.Len() != o.len || s.IsEmpty() != (o.len == 0) {
+					t.Errorf("op %d %s: Len=%d IsEmpty=%v; want Len=%d", i, o.name, s.Len(), s.IsEmpty(), o.len)
+				}
+			}
+		})
+	}
+}
+
+// TestQueue checks FIFO order, Peek, and the empty case.
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+	}{
+		{"empty", nil},
+		{"single", []string{"a"}},
+		{"several", []string{"a", "b", "c"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var q Queue[string]
+			for _, v := range tc.in {
+				q.Enqueue(v)
+			}
+			if q.Len() != len(tc.in) {
+				t.Fatalf("Len = %d; want %d", q.Len(), len(tc.in))
+			}
+			if len(tc.in) > 0 {
+				if v, ok := q.Peek(); !ok || v != tc.in[0] {
+					t.Errorf("Peek = (%q, %v); want (%q, true)", v, ok, tc.in[0])
+				}
+			}
+
+			var out []string
+			for !q.IsEmpty() {
+				v, _ := q.Dequeue()
+				out = append(out, v)
+			}
+			if !slices.Equal(out, tc.in) {
+				t.Errorf("dequeued %v; want %v", out, tc.in)
+			}
+			if v, ok := q.Dequeue(); ok || v != "" {
+				t.Errorf("Dequeue on empty = (%q, %v); want (\"\", false)", v, ok)
+			}
+		})
+	}
+}
+
+// TestSet checks membership and the set algebra operations.
+func TestSet(t *testing.T) {
+	a := NewSet(1, 2, 3, 3) // duplicate is ignored
+	b := NewSet(3, 4)
+
+	tests := []struct {
+		name string
+		set  *Set[int]
+		want []int
+	}{
+		{"NewSet dedupes", a, []int{1, 2, 3}},
+		{"Union", a.Union(b), []int{1, 2, 3, 4}},
+		{"Intersection", a.Intersection(b), []int{3}},
+		{"Difference", a.Difference(b), []int{1, 2}},
+		{"Difference empty", b.Difference(NewSet(3, 4)), []int{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.set.Slice()
+			slices.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v; want %v", got, tc.want)
+			}
+			if tc.set.Len() != len(tc.want) {
+				t.Errorf("Len = %d; want %d", tc.set.Len(), len(tc.want))
+			}
+		})
+	}
+
+	s := NewSet("go")
+	if !s.Contains("go") || s.Contains("rust") {
+		t.Error("Contains reported wrong membership")
+	}
+	s.Remove("go")
+	if s.Contains("go") || s.Len() != 0 {
+		t.Error("Remove did not delete the element")
+	}
+}
+
+// ── Allocation guards ────────────────────────────────────────────────────────
+// These lock in the zero-allocation steady state of the hot paths; a change
+// that makes them allocate (e.g. boxing T in an interface) fails the test.
+
+// TestStackPushPopNoAllocs checks that Push/Pop on a pre-grown Stack reuse
+// the backing array.
+func TestStackPushPopNoAllocs(t *testing.T) {
+	var s Stack[int]
+	for i := 0; i < 64; i++ {
+		s.Push(i)
+	}
+	for !s.IsEmpty() {
+		s.Pop()
+	}
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		s.Push(1)
+		s.Pop()
+	})
+	if allocs != 0 {
+		t.Errorf("Push+Pop on a pre-grown Stack allocated %.1f times per run; want 0", allocs)
+	}
+}
+
+// TestSetAddExistingNoAllocs checks that re-adding an existing key does not
+// allocate.
+func TestSetAddExistingNoAllocs(t *testing.T) {
+	s := NewSet("go", "rust", "zig")
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		s.Add("go")
+	})
+	if allocs != 0 {
+		t.Errorf("Set.Add of an existing key allocated %.1f times per run; want 0", allocs)
+	}
+}
+
+// ── Benchmarks ───────────────────────────────────────────────────────────────
+
+func BenchmarkStackPushPop(b *testing.B) {
+	var s Stack[int]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+		s.Pop()
+	}
+}
+
+func BenchmarkQueueEnqueueDequeue(b *testing.B) {
+	var q Queue[int]
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Enqueue(i)
+		q.Dequeue()
+	}
+}
+
+func BenchmarkSetAdd(b *testing.B) {
+	s := NewSet[int]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(i & 1023) // bounded key space: mostly existing keys
+	}
+}
+
+func BenchmarkSetContains(b *testing.B) {
+	s := NewSet[int]()
+	for i := 0; i < 1024; i++ {
+		s.Add(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(i & 2047)
+	}
+}
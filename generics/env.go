@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ── Load — config struct from env-style key/value lookups ────────────────────
+// Same split as DecodeCSV: the type parameter gives a typed result, reflection
+// reads the `env:"KEY"` and `default:"..."` tags and converts each value.
+
+// EnvError reports a value that could not be converted to its field's type.
+type EnvError struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *EnvError) Error() string {
+	return fmt.Sprintf("env: %s=%q: %v", e.Key, e.Value, e.Err)
+}
+
+func (e *EnvError) Unwrap() error { return e.Err }
+
+// Load builds a T from lookup, which has the shape of os.LookupEnv. Every
+// exported field tagged `env:"KEY"` is set from lookup(KEY), or from its
+// `default:"..."` tag if KEY is missing, or left at its zero value if there
+// is no default either; untagged fields are not touched. Fields may be
+// strings, bools, ints, uints, floats or time.Duration (parsed with
+// time.ParseDuration).
+//
+// If T (or *T) has a Validate() error method, Load calls it on the result
+// and returns its error, wrapped.
+//
+//	type Config struct {
+//	    Port    int           `env:"PORT" default:"8080"`
+//	    Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+//	}
+//	cfg, err := Load[Config](os.LookupEnv)
+func Load[T any](lookup func(key string) (string, bool)) (T, error) {
+	var cfg T
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return cfg, fmt.Errorf("env: %v is not a struct", t)
+	}
+
+	v := reflect.ValueOf(&cfg).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key, ok := f.Tag.Lookup("env")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		s, ok := lookup(key)
+		if !ok {
+			if s, ok = f.Tag.Lookup("default"); !ok {
+				continue
+			}
+		}
+		if err := parseEnv(v.Field(i), s); err != nil {
+			return cfg, &EnvError{Key: key, Value: s, Err: err}
+		}
+	}
+
+	if val, ok := any(&cfg).(interface{ Validate() error }); ok {
+		if err := val.Validate(); err != nil {
+			return cfg, fmt.Errorf("env: invalid %v: %w", t, err)
+		}
+	}
+	return cfg, nil
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// parseEnv is parseCell plus time.Duration, checked first since its Kind is
+// Int64.
+func parseEnv(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case v.Kind() == reflect.String, v.Kind() == reflect.Bool,
+		v.CanInt(), v.CanUint(), v.CanFloat():
+		return parseCell(v, s)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+}
+
+// serverConfig is demoLoad's config; Validate runs after every field is set.
+type serverConfig struct {
+	Host    string        `env:"HOST" default:"localhost"`
+	Port    int           `env:"PORT" default:"8080"`
+	Debug   bool          `env:"DEBUG"`
+	Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+}
+
+func (c serverConfig) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return errors.New("PORT must be between 1 and 65535")
+	}
+	return nil
+}
+
+func demoLoad() {
+	// A map stands in for the process environment (os.LookupEnv).
+	env := func(vars map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			v, ok := vars[key]
+			return v, ok
+		}
+	}
+
+	cfg, err := Load[serverConfig](env(map[string]string{"PORT": "9090", "DEBUG": "true"}))
+	fmt.Printf("  %+v err=%v\n", cfg, err)
+
+	_, err = Load[serverConfig](env(map[string]string{"TIMEOUT": "soon"}))
+	fmt.Printf("  bad value: %v\n", err)
+
+	_, err = Load[serverConfig](env(map[string]string{"PORT": "0"}))
+	fmt.Printf("  invalid:   %v\n", err)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type envConfig struct {
+	Name    string        `env:"NAME"`
+	Workers int           `env:"WORKERS" default:"4"`
+	Verbose bool          `env:"VERBOSE" default:"false"`
+	Timeout time.Duration `env:"TIMEOUT" default:"2s"`
+	Ratio   float64       `env:"RATIO"`
+	Local   string        // no env tag: never set
+}
+
+func (c envConfig) Validate() error {
+	if c.Workers <= 0 {
+		return errors.New("WORKERS must be positive")
+	}
+	return nil
+}
+
+// fakeEnv returns a lookup over vars, shaped like os.LookupEnv.
+func fakeEnv(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+// TestLoadSetsFieldsAndDefaults checks that present keys are converted into
+// string, int, bool, duration and float fields, that missing keys take
+// their default, and that untagged fields stay untouched.
+func TestLoadSetsFieldsAndDefaults(t *testing.T) {
+	got, err := Load[envConfig](fakeEnv(map[string]string{
+		"NAME":    "api",
+		"VERBOSE": "true",
+		"TIMEOUT": "1m30s",
+		"RATIO":   "0.25",
+	}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := envConfig{Name: "api", Workers: 4, Verbose: true, Timeout: 90 * time.Second, Ratio: 0.25}
+	if got != want {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+// TestLoadBadValue checks that an unparseable value is reported as an
+// *EnvError naming the key and wrapping the parse error.
+func TestLoadBadValue(t *testing.T) {
+	_, err := Load[envConfig](fakeEnv(map[string]string{"WORKERS": "many"}))
+
+	var eerr *EnvError
+	if !errors.As(err, &eerr) {
+		t.Fatalf("err = %v; want *EnvError", err)
+	}
+	if eerr.Key != "WORKERS" || eerr.Value != "many" {
+		t.Errorf("key, value = %q, %q; want %q, %q", eerr.Key, eerr.Value, "WORKERS", "many")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("err = %v; want it to wrap strconv.ErrSyntax", err)
+	}
+
+	if _, err := Load[envConfig](fakeEnv(map[string]string{"TIMEOUT": "soon"})); !errors.As(err, &eerr) || eerr.Key != "TIMEOUT" {
+		t.Errorf("bad duration: err = %v; want *EnvError for TIMEOUT", err)
+	}
+}
+
+// TestLoadValidate checks that Validate runs on the loaded value and that
+// its error is returned.
+func TestLoadValidate(t *testing.T) {
+	_, err := Load[envConfig](fakeEnv(map[string]string{"WORKERS": "0"}))
+	if err == nil || !strings.Contains(err.Error(), "WORKERS must be positive") {
+		t.Errorf("err = %v; want the Validate error", err)
+	}
+}
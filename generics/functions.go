@@ -62,6 +62,28 @@ func Values[K comparable, V any](m map[K]V) []V {
 	return out
 }
 
+// Diff compares two versions of a collection — the desired and the actual
+// state in a reconciliation loop — and returns what to create and delete:
+// added holds the elements of new missing from old, removed those of old
+// missing from new. Each keeps the order of its source slice; an element
+// repeated in its source is reported once.
+func Diff[T comparable](old, new []T) (added, removed []T) {
+	return missingFrom(new, NewSet(old...)), missingFrom(old, NewSet(new...))
+}
+
+// missingFrom returns the elements of s not in other, in order, each once.
+func missingFrom[T comparable](s []T, other *Set[T]) []T {
+	var out []T
+	seen := NewSet[T]()
+	for _, v := range s {
+		if !other.Contains(v) && !seen.Contains(v) {
+			seen.Add(v)
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // Must unwraps (value, error), panicking if err != nil.
 // Useful for initialization paths that should never fail.
 //
@@ -106,6 +128,13 @@ func demoFunctions() {
 	fmt.Println("  len(Keys(m))   =", len(Keys(m)))
 	fmt.Println("  len(Values(m)) =", len(Values(m)))
 
+	fmt.Println("\n  Diff — reconcile desired vs actual:")
+	actual := []string{"web-1", "web-2", "db-1"}
+	desired := []string{"web-1", "web-3", "db-1", "cache-1"}
+	added, removed := Diff(actual, desired)
+	fmt.Println("  added   =", added)
+	fmt.Println("  removed =", removed)
+
 	fmt.Println("\n  Must — unwrap (value, error):")
 	fmt.Println("  Must(42, nil)  =", Must(42, nil))
 }
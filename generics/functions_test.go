@@ -0,0 +1,32 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDiff checks the added/removed partitions for overlapping, disjoint and
+// identical slices, and that each keeps the order of its source slice.
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		old, new       []int
+		added, removed []int
+	}{
+		{"overlapping", []int{5, 1, 4, 2}, []int{3, 4, 9, 1, 7}, []int{3, 9, 7}, []int{5, 2}},
+		{"disjoint", []int{3, 1, 2}, []int{6, 5, 4}, []int{6, 5, 4}, []int{3, 1, 2}},
+		{"identical", []int{1, 2, 3}, []int{1, 2, 3}, nil, nil},
+		{"same set, reordered", []int{1, 2, 3}, []int{3, 1, 2}, nil, nil},
+		{"duplicates reported once", []int{1, 1, 2}, []int{3, 3, 1}, []int{3}, []int{2}},
+		{"empty old", nil, []int{2, 1}, []int{2, 1}, nil},
+	}
+	for _, tt := range tests {
+		added, removed := Diff(tt.old, tt.new)
+		if !slices.Equal(added, tt.added) {
+			t.Errorf("%s: added = %v; want %v", tt.name, added, tt.added)
+		}
+		if !slices.Equal(removed, tt.removed) {
+			t.Errorf("%s: removed = %v; want %v", tt.name, removed, tt.removed)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ── Future[T] — a value that will be available later ─────────────────────────
+// A Future is the read side of a one-shot result: whoever holds it can wait
+// for the value; whoever holds the resolve function (the "promise") sets it,
+// exactly once. Internally it is the classic close-a-channel broadcast: every
+// Get blocked on done wakes up when resolve closes it.
+
+// Future holds the eventual result of an asynchronous computation.
+type Future[T any] struct {
+	done     chan struct{} // closed by resolve; value and err are set before
+	value    T
+	err      error
+	resolved atomic.Bool
+}
+
+// NewFuture returns an unresolved future and the function that resolves it.
+// Calling resolve more than once panics: a second result would be silently
+// lost otherwise.
+func NewFuture[T any]() (*Future[T], func(T, error)) {
+	f := &Future[T]{done: make(chan struct{})}
+	resolve := func(v T, err error) {
+		if !f.resolved.CompareAndSwap(false, true) {
+			panic("future: resolved twice")
+		}
+		f.value, f.err = v, err
+		close(f.done) // publishes value and err to every Get
+	}
+	return f, resolve
+}
+
+// Async runs fn in a new goroutine and returns a future for its result.
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f, resolve := NewFuture[T]()
+	go func() { resolve(fn()) }()
+	return f
+}
+
+// Get blocks until the future is resolved or ctx is done. It can be called
+// any number of times, from any goroutine; all callers see the same result.
+// On cancellation it returns ctx.Err() and the future stays usable.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	// Prefer an available result over a cancelled ctx: select picks randomly
+	// when both are ready.
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel closed once the future is resolved, for use in a
+// select alongside other channels.
+func (f *Future[T]) Done() <-chan struct{} { return f.done }
+
+// ── Combinators — All / Any / Race ──────────────────────────────────────────
+
+// ErrNoFutures is returned by AnyFuture and RaceFutures when given no futures.
+var ErrNoFutures = errors.New("future: no futures given")
+
+// outcome is one settled future, tagged with its position in the input.
+type outcome[T any] struct {
+	i     int
+	value T
+	err   error
+}
+
+// settlements waits on every future in its own goroutine and sends each
+// outcome, in settlement order, on the returned channel. The channel is
+// buffered for all of them, so the goroutines never block on send; cancelling
+// ctx releases the ones still waiting on Get.
+func settlements[T any](ctx context.Context, futures []*Future[T]) <-chan outcome[T] {
+	out := make(chan outcome[T], len(futures))
+	for i, f := range futures {
+		go func() {
+			v, err := f.Get(ctx)
+			out <- outcome[T]{i, v, err}
+		}()
+	}
+	return out
+}
+
+// AllFutures waits for every future and returns their values in input order.
+// It returns early with the first error to settle — or ctx.Err() — without
+// waiting for the rest.
+func AllFutures[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	values := make([]T, len(futures))
+	results := settlements(ctx, futures)
+	for range futures {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		values[r.i] = r.value
+	}
+	return values, nil
+}
+
+// AnyFuture returns the value of the first future to succeed. Failures are
+// ignored unless every future fails, in which case it returns all their
+// errors joined. ctx.Err() is returned if ctx ends first.
+func AnyFuture[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	var zero T
+	if len(futures) == 0 {
+		return zero, ErrNoFutures
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(futures))
+	results := settlements(ctx, futures)
+	for range futures {
+		r := <-results
+		if r.err == nil {
+			return r.value, nil
+		}
+		if ctx.Err() != nil { // the parent was cancelled; stop collecting
+			return zero, ctx.Err()
+		}
+		errs[r.i] = r.err
+	}
+	return zero, errors.Join(errs...)
+}
+
+// RaceFutures returns the result of the first future to settle, success or
+// failure, or ctx.Err() if ctx ends first.
+func RaceFutures[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	if len(futures) == 0 {
+		var zero T
+		return zero, ErrNoFutures
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := <-settlements(ctx, futures)
+	return r.value, r.err
+}
+
+func demoFuture() {
+	price := Async(func() (float64, error) {
+		time.Sleep(20 * time.Millisecond) // slow pricing service
+		return 19.99, nil
+	})
+	fmt.Println("  Async started; doing other work while it runs...")
+	v, err := price.Get(context.Background())
+	fmt.Printf("  price.Get() = %.2f, %v\n", v, err)
+
+	// Manual resolution: the future is handed out before the value exists.
+	f, resolve := NewFuture[string]()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		resolve("", errors.New("quota exceeded"))
+	}()
+	_, err = f.Get(context.Background())
+	fmt.Printf("  resolved with error: %v\n", err)
+
+	// A deadline shorter than the computation: Get gives up, the future doesn't.
+	slow := Async(func() (int, error) {
+		time.Sleep(50 * time.Millisecond)
+		return 1, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = slow.Get(ctx)
+	fmt.Printf("  Get with 5ms timeout: %v\n", err)
+
+	// Combinators: three mirrors of the same file, one of them down.
+	mirror := func(name string, d time.Duration, err error) *Future[string] {
+		return Async(func() (string, error) {
+			time.Sleep(d)
+			return name, err
+		})
+	}
+	bg := context.Background()
+
+	all, err := AllFutures(bg, mirror("eu", 10*time.Millisecond, nil), mirror("us", 20*time.Millisecond, nil))
+	fmt.Printf("  AllFutures  = %v, %v\n", all, err)
+
+	winner, err := AnyFuture(bg,
+		mirror("down", time.Millisecond, errors.New("mirror down")),
+		mirror("eu", 10*time.Millisecond, nil))
+	fmt.Printf("  AnyFuture   = %q, %v (the early failure is ignored)\n", winner, err)
+
+	first, err := RaceFutures(bg,
+		mirror("down", time.Millisecond, errors.New("mirror down")),
+		mirror("eu", 10*time.Millisecond, nil))
+	fmt.Printf("  RaceFutures = %q, %v (first to settle wins, even a failure)\n", first, err)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestFutureResolveThenGet checks that Get returns at once, repeatedly, once
+// the future has been resolved.
+func TestFutureResolveThenGet(t *testing.T) {
+	f, resolve := NewFuture[int]()
+	resolve(42, nil)
+
+	for i := 0; i < 2; i++ {
+		if v, err := f.Get(context.Background()); v != 42 || err != nil {
+			t.Errorf("Get #%d = (%v, %v); want (42, <nil>)", i+1, v, err)
+		}
+	}
+}
+
+// TestFutureGetThenResolve checks that a Get blocked before resolution wakes
+// up with the resolved value and error.
+func TestFutureGetThenResolve(t *testing.T) {
+	f, resolve := NewFuture[string]()
+	errBoom := errors.New("boom")
+
+	got := make(chan error, 1)
+	go func() {
+		_, err := f.Get(context.Background())
+		got <- err
+	}()
+
+	select {
+	case err := <-got:
+		t.Fatalf("Get returned %v before resolve", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	resolve("", errBoom)
+	select {
+	case err := <-got:
+		if !errors.Is(err, errBoom) {
+			t.Errorf("got %v; want %v", err, errBoom)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Get did not return after resolve")
+	}
+}
+
+// TestFutureDoubleResolvePanics checks that resolving a future twice panics
+// and the first result is kept.
+func TestFutureDoubleResolvePanics(t *testing.T) {
+	f, resolve := NewFuture[int]()
+	resolve(1, nil)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("second resolve did not panic")
+			}
+		}()
+		resolve(2, nil)
+	}()
+
+	if v, _ := f.Get(context.Background()); v != 1 {
+		t.Errorf("got %v; want 1 (first resolution)", v)
+	}
+}
+
+// TestFutureGetCancelled checks that Get on an unresolved future returns
+// ctx.Err() when its context is cancelled.
+func TestFutureGetCancelled(t *testing.T) {
+	f, _ := NewFuture[int]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v; want %v", err, context.Canceled)
+	}
+}
+
+// TestAsync checks that Async delivers fn's result through the future.
+func TestAsync(t *testing.T) {
+	f := Async(func() (string, error) { return "done", nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if v, err := f.Get(ctx); v != "done" || err != nil {
+		t.Errorf("got (%q, %v); want (\"done\", <nil>)", v, err)
+	}
+}
+
+// after returns a future resolved with (v, err) after d.
+func after[T any](d time.Duration, v T, err error) *Future[T] {
+	return Async(func() (T, error) {
+		time.Sleep(d)
+		return v, err
+	})
+}
+
+// TestAllFuturesSucceed checks that AllFutures returns every value in input
+// order, not settlement order.
+func TestAllFuturesSucceed(t *testing.T) {
+	got, err := AllFutures(context.Background(),
+		after(30*time.Millisecond, 1, nil),
+		after(10*time.Millisecond, 2, nil),
+		after(20*time.Millisecond, 3, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestAllFuturesFirstError checks that AllFutures fails with the first error
+// to settle without waiting for the slow future.
+func TestAllFuturesFirstError(t *testing.T) {
+	errBoom := errors.New("boom")
+	start := time.Now()
+
+	_, err := AllFutures(context.Background(),
+		after(time.Second, 1, nil),
+		after(5*time.Millisecond, 0, errBoom))
+	if !errors.Is(err, errBoom) {
+		t.Errorf("got %v; want %v", err, errBoom)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("AllFutures took %v; want it to return on the first error", d)
+	}
+}
+
+// TestAnyFutureIgnoresFailures checks that AnyFuture skips earlier failures
+// and returns the first success.
+func TestAnyFutureIgnoresFailures(t *testing.T) {
+	got, err := AnyFuture(context.Background(),
+		after(time.Millisecond, "", errors.New("a down")),
+		after(5*time.Millisecond, "", errors.New("b down")),
+		after(20*time.Millisecond, "c", nil))
+	if got != "c" || err != nil {
+		t.Errorf("got (%q, %v); want (\"c\", <nil>)", got, err)
+	}
+}
+
+// TestAnyFutureAllFail checks that when every future fails, AnyFuture returns
+// an error wrapping all of them.
+func TestAnyFutureAllFail(t *testing.T) {
+	errA, errB := errors.New("a down"), errors.New("b down")
+
+	_, err := AnyFuture(context.Background(),
+		after(time.Millisecond, 0, errA),
+		after(5*time.Millisecond, 0, errB))
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("got %v; want it to wrap both %v and %v", err, errA, errB)
+	}
+}
+
+// TestRaceFuturesFastest checks that RaceFutures returns whichever future
+// settles first — a failure as well as a success.
+func TestRaceFuturesFastest(t *testing.T) {
+	got, err := RaceFutures(context.Background(),
+		after(50*time.Millisecond, "slow", nil),
+		after(time.Millisecond, "fast", nil))
+	if got != "fast" || err != nil {
+		t.Errorf("got (%q, %v); want (\"fast\", <nil>)", got, err)
+	}
+
+	errBoom := errors.New("boom")
+	_, err = RaceFutures(context.Background(),
+		after(50*time.Millisecond, "slow", nil),
+		after(time.Millisecond, "", errBoom))
+	if !errors.Is(err, errBoom) {
+		t.Errorf("got %v; want %v (a fast failure wins the race)", err, errBoom)
+	}
+}
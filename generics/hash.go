@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// ── Hash[T] + HashMap[K, V] — map keys that aren't comparable ────────────────
+// Slices, maps and funcs can't be map keys. Hash folds any value into a
+// uint64 by walking it with reflection; HashMap buckets entries by that hash
+// and settles collisions with an equality function.
+
+// Hash returns a 64-bit FNV-1a hash of v's contents. Values that are equal by
+// reflect.DeepEqual hash equally: slices and arrays by their elements, maps
+// regardless of iteration order, structs by every field (exported or not),
+// pointers and interfaces by what they hold. Funcs hash only by nil-ness and
+// channels by identity.
+//
+// The hash is stable within one build, not across Go versions or program
+// changes, so don't persist it. Different values can collide; callers that
+// key on it must also compare the values, as HashMap does. v must not
+// contain a pointer cycle.
+func Hash[T any](v T) uint64 {
+	h := fnv.New64a()
+	hashValue(h, reflect.ValueOf(&v).Elem())
+	return h.Sum64()
+}
+
+// hashValue writes v to h. Each value starts with its kind, and variable-size
+// values with their length, so adjacent values cannot blur into each other
+// (["ab", "c"] and ["a", "bc"] hash differently).
+func hashValue(h hash.Hash64, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(x uint64) {
+		binary.LittleEndian.PutUint64(buf[:], x)
+		h.Write(buf[:])
+	}
+	h.Write([]byte{byte(v.Kind())})
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeUint(floatBits(real(c)))
+		writeUint(floatBits(imag(c)))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Slice, reflect.Array:
+		writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Map:
+		// Iteration order is random: hash each entry on its own and add the
+		// results, which doesn't depend on order.
+		writeUint(uint64(v.Len()))
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := fnv.New64a()
+			hashValue(eh, iter.Key())
+			hashValue(eh, iter.Value())
+			sum += eh.Sum64()
+		}
+		writeUint(sum)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+		}
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		writeUint(1)
+		if v.Kind() == reflect.Interface {
+			h.Write([]byte(v.Elem().Type().String()))
+		}
+		hashValue(h, v.Elem())
+	case reflect.Func:
+		if v.IsNil() {
+			writeUint(0)
+		} else {
+			writeUint(1)
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	}
+}
+
+// floatBits returns the bits of f with -0 folded into +0, since they are
+// equal.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// HashMap is a map keyed by values of any type, including slices, maps and
+// structs containing them. Entries are bucketed by Hash; keys within a bucket
+// are told apart by the equality function. It is not safe for concurrent use.
+type HashMap[K, V any] struct {
+	equal func(a, b K) bool
+	hash  func(K) uint64 // overridable in tests
+
+	buckets map[uint64][]hashEntry[K, V]
+	n       int
+}
+
+type hashEntry[K, V any] struct {
+	key K
+	val V
+}
+
+// NewHashMap returns an empty map whose keys are compared with equal, or
+// with reflect.DeepEqual if equal is nil. equal must agree with Hash: keys it
+// considers equal must hash equally.
+func NewHashMap[K, V any](equal func(a, b K) bool) *HashMap[K, V] {
+	if equal == nil {
+		equal = func(a, b K) bool { return reflect.DeepEqual(a, b) }
+	}
+	return &HashMap[K, V]{equal: equal, hash: Hash[K], buckets: make(map[uint64][]hashEntry[K, V])}
+}
+
+// Get returns the value stored under k and whether it was present.
+func (m *HashMap[K, V]) Get(k K) (V, bool) {
+	for _, e := range m.buckets[m.hash(k)] {
+		if m.equal(e.key, k) {
+			return e.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores v under k, replacing any previous value.
+func (m *HashMap[K, V]) Set(k K, v V) {
+	h := m.hash(k)
+	bucket := m.buckets[h]
+	for i := range bucket {
+		if m.equal(bucket[i].key, k) {
+			bucket[i].val = v
+			return
+		}
+	}
+	m.buckets[h] = append(bucket, hashEntry[K, V]{k, v})
+	m.n++
+}
+
+// Delete removes k and reports whether it was present.
+func (m *HashMap[K, V]) Delete(k K) bool {
+	h := m.hash(k)
+	bucket := m.buckets[h]
+	for i := range bucket {
+		if m.equal(bucket[i].key, k) {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			if len(bucket) == 0 {
+				delete(m.buckets, h)
+			} else {
+				m.buckets[h] = bucket
+			}
+			m.n--
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of entries.
+func (m *HashMap[K, V]) Len() int { return m.n }
+
+func demoHashMap() {
+	fmt.Printf("  Hash([]int{1,2,3}) == Hash([]int{1,2,3}): %v\n",
+		Hash([]int{1, 2, 3}) == Hash([]int{1, 2, 3}))
+	fmt.Printf("  Hash(map a,b) == Hash(same map, built in reverse): %v\n",
+		Hash(map[string]int{"a": 1, "b": 2}) == Hash(map[string]int{"b": 2, "a": 1}))
+
+	// Count how often each path (a []string) was visited.
+	visits := NewHashMap[[]string, int](nil)
+	for _, path := range [][]string{{"home"}, {"home", "cart"}, {"home"}, {"home", "cart"}, {"home"}} {
+		n, _ := visits.Get(path)
+		visits.Set(path, n+1)
+	}
+	for _, path := range [][]string{{"home"}, {"home", "cart"}, {"about"}} {
+		n, ok := visits.Get(path)
+		fmt.Printf("  visits%v = %d (found=%v)\n", path, n, ok)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+type hashProbe struct {
+	Name  string
+	Tags  []string
+	Attrs map[string]int
+	next  *hashProbe
+}
+
+// TestHashEqualValuesHashEqually builds pairs of equal but separately
+// allocated values — including maps filled in different orders — and checks
+// their hashes match, while a few near misses hash differently.
+func TestHashEqualValuesHashEqually(t *testing.T) {
+	build := func(keys ...string) hashProbe {
+		attrs := map[string]int{}
+		for _, k := range keys {
+			attrs[k] = len(k)
+		}
+		return hashProbe{Name: "x", Tags: []string{"p", "q"}, Attrs: attrs, next: &hashProbe{Name: "child"}}
+	}
+
+	if a, b := Hash(build("a", "bb", "ccc")), Hash(build("ccc", "bb", "a")); a != b {
+		t.Errorf("equal structs: Hash = %x, %x; want equal", a, b)
+	}
+	if a, b := Hash([]int{1, 2, 3}), Hash([]int{1, 2, 3}); a != b {
+		t.Errorf("equal slices: Hash = %x, %x; want equal", a, b)
+	}
+	if a, b := Hash[any](1), Hash[any](1); a != b {
+		t.Errorf("equal interfaces: Hash = %x, %x; want equal", a, b)
+	}
+
+	differ := []struct {
+		name string
+		a, b uint64
+	}{
+		{"slice order", Hash([]int{1, 2}), Hash([]int{2, 1})},
+		{"string boundaries", Hash([]string{"ab", "c"}), Hash([]string{"a", "bc"})},
+		{"dynamic type", Hash[any](int32(1)), Hash[any](int64(1))},
+		{"unexported field", Hash(hashProbe{next: &hashProbe{Name: "a"}}), Hash(hashProbe{next: &hashProbe{Name: "b"}})},
+	}
+	for _, d := range differ {
+		if d.a == d.b {
+			t.Errorf("%s: hashes are equal (%x); want different", d.name, d.a)
+		}
+	}
+}
+
+// TestHashMapSliceKeys stores values under slice keys and checks Get, Set
+// (overwrite), Delete and Len.
+func TestHashMapSliceKeys(t *testing.T) {
+	m := NewHashMap[[]string, int](nil)
+	m.Set([]string{"a", "b"}, 1)
+	m.Set([]string{"a"}, 2)
+	m.Set([]string{"a", "b"}, 3) // a fresh but equal slice overwrites
+
+	if got, ok := m.Get([]string{"a", "b"}); !ok || got != 3 {
+		t.Errorf("Get([a b]) = %d, %v; want 3, true", got, ok)
+	}
+	if got, ok := m.Get([]string{"a"}); !ok || got != 2 {
+		t.Errorf("Get([a]) = %d, %v; want 2, true", got, ok)
+	}
+	if _, ok := m.Get([]string{"b", "a"}); ok {
+		t.Error("Get([b a]) found an entry; want none")
+	}
+	if got := m.Len(); got != 2 {
+		t.Errorf("Len = %d; want 2", got)
+	}
+
+	if !m.Delete([]string{"a"}) || m.Delete([]string{"a"}) {
+		t.Error("Delete([a]) twice = want true then false")
+	}
+	if got := m.Len(); got != 1 {
+		t.Errorf("Len after Delete = %d; want 1", got)
+	}
+}
+
+// TestHashMapCollisions forces every key into one bucket and checks that the
+// equality function still keeps the entries apart.
+func TestHashMapCollisions(t *testing.T) {
+	m := NewHashMap[[]int, string](slices.Equal[[]int])
+	m.hash = func([]int) uint64 { return 42 }
+
+	m.Set([]int{1}, "one")
+	m.Set([]int{2}, "two")
+	m.Set([]int{3}, "three")
+	m.Delete([]int{2})
+
+	for _, tc := range []struct {
+		key  []int
+		want string
+		ok   bool
+	}{
+		{[]int{1}, "one", true},
+		{[]int{2}, "", false},
+		{[]int{3}, "three", true},
+	} {
+		if got, ok := m.Get(tc.key); got != tc.want || ok != tc.ok {
+			t.Errorf("Get(%v) = %q, %v; want %q, %v", tc.key, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// ── iter.Seq[T] — lazy sequences (Go 1.23) ───────────────────────────────────
+// An iter.Seq[T] is just func(yield func(T) bool). The producer calls yield
+// for each element and must stop as soon as yield returns false (the consumer
+// broke out of its range loop).
+//
+// Unlike Map/Filter on slices, the Seq variants allocate no intermediate
+// slice: each element flows through the whole chain before the next one is
+// produced.
+
+// MapSeq lazily transforms every element of seq using f.
+func MapSeq[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq lazily yields the elements of seq for which f returns true.
+func FilterSeq[T any](seq iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if f(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// RateLimited yields the elements of seq at most once per interval. The first
+// element is yielded immediately; each following one waits until interval has
+// elapsed since the previous yield.
+//
+// Pacing happens before pulling the next element from the consumer's point of
+// view, so a consumer that breaks out of the range loop stops the sequence
+// right away — no trailing sleep.
+func RateLimited[T any](seq iter.Seq[T], interval time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var last time.Time
+		for v := range seq {
+			if !last.IsZero() {
+				if wait := interval - time.Since(last); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+			last = time.Now()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func demoIter() {
+	nums := slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	fmt.Println("  FilterSeq → MapSeq — squares of evens (lazy, no intermediate slice):")
+	evens := FilterSeq(nums, func(n int) bool { return n%2 == 0 })
+	squares := MapSeq(evens, func(n int) int { return n * n })
+	fmt.Println("  ", slices.Collect(squares))
+
+	fmt.Println("\n  RateLimited — one element every 20ms:")
+	start := time.Now()
+	for v := range RateLimited(squares, 20*time.Millisecond) {
+		fmt.Printf("    %3d at +%v\n", v, time.Since(start).Round(10*time.Millisecond))
+	}
+}
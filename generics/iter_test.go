@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestMapFilterSeq checks that the lazy helpers match their slice versions.
+func TestMapFilterSeq(t *testing.T) {
+	nums := []int{1, 2, 3, 4, 5, 6}
+	isEven := func(n int) bool { return n%2 == 0 }
+	double := func(n int) int { return n * 2 }
+
+	got := slices.Collect(MapSeq(FilterSeq(slices.Values(nums), isEven), double))
+	want := Map(Filter(nums, isEven), double)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+// TestRateLimitedPacing verifies that consuming N elements takes at least
+// (N-1)*interval and that every element is yielded in order.
+func TestRateLimitedPacing(t *testing.T) {
+	const n = 5
+	const interval = 20 * time.Millisecond
+
+	start := time.Now()
+	var got []int
+	for v := range RateLimited(slices.Values([]int{1, 2, 3, 4, 5}), interval) {
+		got = append(got, v)
+	}
+	elapsed := time.Since(start)
+
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("got %v; want [1 2 3 4 5]", got)
+	}
+	if least := (n - 1) * interval; elapsed < least {
+		t.Errorf("consumed %d elements in %s; want at least %s", n, elapsed, least)
+	}
+}
+
+// TestRateLimitedEarlyBreak verifies that breaking out of the range loop stops
+// the sequence immediately, even over an infinite source.
+func TestRateLimitedEarlyBreak(t *testing.T) {
+	const interval = time.Second
+
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	start := time.Now()
+	for v := range RateLimited(naturals, interval) {
+		if v == 0 {
+			break
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Errorf("early break took %s; want well under %s", elapsed, interval)
+	}
+}
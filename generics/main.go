@@ -11,14 +11,59 @@ func main() {
 	section("Constraints — any, comparable, ~T, union, method")
 	demoConstraints()
 
-	section("Functions — Map, Filter, Reduce, Contains, Keys/Values, Must")
+	section("ConvertInt — integer conversion with overflow detection")
+	demoConvert()
+
+	section("Functions — Map, Filter, Reduce, Contains, Keys/Values, Diff, Must")
 	demoFunctions()
 
 	section("Data structures — Stack[T], Queue[T], Set[T comparable]")
 	demoDataStructs()
 
+	section("Bag[T comparable] — multiset: Count, Distinct, MostCommon")
+	demoBag()
+
+	section("SortedSet[T] — ordered set on a skip list")
+	demoSortedSet()
+
 	section("Patterns — inference, multiple params, zero value, Result[T], limitations")
 	demoPatterns()
+
+	section("Iterators — MapSeq, FilterSeq, RateLimited (iter.Seq, Go 1.23)")
+	demoIter()
+
+	section("Tree[T] — DFS/BFS as iter.Seq[T]")
+	demoTree()
+
+	section("Channels — CollectByKey: Pair[K, V] stream → map[K]V; Fold")
+	demoCollect()
+
+	section("Memoization — Memoize, Memoize2, Key(parts ...any)")
+	demoMemo()
+
+	section("Hash[T] + HashMap[K, V] — keys that aren't comparable")
+	demoHashMap()
+
+	section("Cache[K, V] — GetOrLoad: TTL + singleflight, errors not cached")
+	demoCache()
+
+	section("TieredCache[K, V] — LRU L1 + pluggable L2 + loader")
+	demoTiered()
+
+	section("TTLSet[T] — deduplication within a time window")
+	demoTTLSet()
+
+	section("RetryResult — retry + Result[T], Backoff")
+	demoRetry()
+
+	section("Future[T] — NewFuture, Async, Get(ctx), All/Any/Race")
+	demoFuture()
+
+	section("EncodeCSV / DecodeCSV — []T ↔ CSV via `csv` struct tags")
+	demoCSV()
+
+	section("Load[T] — config struct from env vars via `env`/`default` tags")
+	demoLoad()
 }
 
 func section(title string) {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ── Memoization — Memoize[K, V] + composite keys ─────────────────────────────
+// Memoize caches a single-argument function keyed by its comparable argument.
+// Functions of several arguments are memoized by first folding the arguments
+// into one string with Key.
+
+// Memoize returns a function that calls f at most once per distinct argument
+// and serves later calls from a cache. It is safe for concurrent use; f runs
+// outside the lock, so two goroutines missing on the same key may both call
+// it (the last result wins, which is fine for pure functions).
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	var mu sync.Mutex
+	cache := make(map[K]V)
+
+	return func(k K) V {
+		mu.Lock()
+		v, ok := cache[k]
+		mu.Unlock()
+		if ok {
+			return v
+		}
+
+		v = f(k)
+		mu.Lock()
+		cache[k] = v
+		mu.Unlock()
+		return v
+	}
+}
+
+// Key builds a deterministic cache key from parts. Each part is written as
+// its dynamic type plus its Go-syntax value (%T=%#v), so values that print
+// the same but differ in type never collide:
+//
+//	Key(1)   → `int=1`
+//	Key("1") → `string="1"`
+//
+// Strings are quoted, so a separator inside a string cannot fake a boundary
+// between parts (Key("a|b") != Key("a", "b")). Parts should be comparable
+// values; pointers are keyed by address, not by what they point to.
+func Key(parts ...any) string {
+	var b strings.Builder
+	for i, p := range parts {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+		fmt.Fprintf(&b, "%T=%#v", p, p)
+	}
+	return b.String()
+}
+
+// Memoize2 memoizes a two-argument function by folding its arguments with
+// Key. A and B need not be comparable as a pair, only formattable
+// deterministically.
+func Memoize2[A, B, V any](f func(A, B) V) func(A, B) V {
+	var mu sync.Mutex
+	cache := make(map[string]V)
+
+	return func(a A, b B) V {
+		k := Key(a, b)
+		mu.Lock()
+		v, ok := cache[k]
+		mu.Unlock()
+		if ok {
+			return v
+		}
+
+		v = f(a, b)
+		mu.Lock()
+		cache[k] = v
+		mu.Unlock()
+		return v
+	}
+}
+
+func demoMemo() {
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+	fmt.Println("  square(4), square(4), square(5) =", square(4), square(4), square(5))
+	fmt.Printf("  underlying func ran %d times\n", calls)
+
+	calls = 0
+	repeat := Memoize2(func(s string, n int) string {
+		calls++
+		return strings.Repeat(s, n)
+	})
+	fmt.Println("  repeat(\"ab\", 3) =", repeat("ab", 3))
+	fmt.Println("  repeat(\"ab\", 3) =", repeat("ab", 3), "(cached)")
+	fmt.Printf("  underlying func ran %d times\n", calls)
+
+	fmt.Printf("  Key(1) = %s   Key(\"1\") = %s\n", Key(1), Key("1"))
+}
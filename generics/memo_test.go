@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+// TestKeyDistinguishesTypes checks that values with the same printed form but
+// different types, or different part boundaries, never share a key.
+func TestKeyDistinguishesTypes(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []any
+	}{
+		{"int vs string", []any{1}, []any{"1"}},
+		{"int vs int64", []any{1}, []any{int64(1)}},
+		{"int vs float", []any{1}, []any{1.0}},
+		{"bool vs string", []any{true}, []any{"true"}},
+		{"nil vs string", []any{nil}, []any{"<nil>"}},
+		{"separator in string", []any{"a|b"}, []any{"a", "b"}},
+		{"argument order", []any{1, 2}, []any{2, 1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if ka, kb := Key(tc.a...), Key(tc.b...); ka == kb {
+				t.Errorf("Key(%v) == Key(%v) == %q; want different keys", tc.a, tc.b, ka)
+			}
+		})
+	}
+}
+
+// TestKeyDeterministic checks that identical arguments always build the same
+// key.
+func TestKeyDeterministic(t *testing.T) {
+	type point struct{ X, Y int }
+
+	args := []any{42, "user", point{1, 2}, true}
+	want := Key(args...)
+	for i := 0; i < 10; i++ {
+		if got := Key(42, "user", point{1, 2}, true); got != want {
+			t.Fatalf("got %q; want %q", got, want)
+		}
+	}
+}
+
+// TestMemoize2 checks that a two-argument function runs once per distinct
+// argument pair.
+func TestMemoize2(t *testing.T) {
+	calls := 0
+	add := Memoize2(func(a, b int) int {
+		calls++
+		return a + b
+	})
+
+	for _, c := range []struct{ a, b, want int }{
+		{1, 2, 3}, {1, 2, 3}, {2, 1, 3}, {1, 2, 3},
+	} {
+		if got := add(c.a, c.b); got != c.want {
+			t.Errorf("add(%d, %d) = %d; want %d", c.a, c.b, got, c.want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("underlying func ran %d times; want 2", calls)
+	}
+}
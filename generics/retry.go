@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ── RetryResult — retry + Result[T] ──────────────────────────────────────────
+// A flaky operation that produces a value (fetch a token, read a config) is
+// usually retried a few times and then either used or reported. RetryResult
+// packages that loop and hands back a single Result[T].
+
+// Backoff returns how long to wait after the given failed attempt (1-based)
+// before the next one.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff waits d between every attempt.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration { return d }
+}
+
+// ExponentialBackoff waits base, 2·base, 4·base, … capped at max.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base << (attempt - 1)
+		if d <= 0 || d > max { // d <= 0 catches shift overflow
+			return max
+		}
+		return d
+	}
+}
+
+// RetryResult calls op up to attempts times, waiting backoff(i) after the
+// i-th failure, and returns Ok with the first successful value. If every
+// attempt fails it returns Err with the last error. If ctx is cancelled
+// first, it stops at once and returns Err wrapping both ctx.Err() and the
+// last error from op, so errors.Is works for either.
+func RetryResult[T any](ctx context.Context, attempts int, backoff Backoff, op func(context.Context) (T, error)) Result[T] {
+	lastErr := errors.New("retry: no attempts made")
+
+	for i := 1; i <= attempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return Err[T](fmt.Errorf("%w (last error: %w)", err, lastErr))
+		}
+
+		v, err := op(ctx)
+		if err == nil {
+			return Ok(v)
+		}
+		lastErr = err
+
+		if i == attempts {
+			break // no wait after the final attempt
+		}
+		timer := time.NewTimer(backoff(i))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return Err[T](fmt.Errorf("%w (last error: %w)", ctx.Err(), lastErr))
+		}
+	}
+	return Err[T](lastErr)
+}
+
+func demoRetry() {
+	calls := 0
+	flaky := func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", fmt.Errorf("attempt %d: service unavailable", calls)
+		}
+		return "token-abc", nil
+	}
+
+	r := RetryResult(context.Background(), 5, ExponentialBackoff(10*time.Millisecond, time.Second), flaky)
+	fmt.Printf("  succeeded on attempt %d: Ok=%v value=%q\n", calls, r.IsOk(), r.Value)
+
+	calls = 0
+	broken := func(ctx context.Context) (int, error) {
+		calls++
+		return 0, fmt.Errorf("attempt %d: disk full", calls)
+	}
+	r2 := RetryResult(context.Background(), 3, ConstantBackoff(5*time.Millisecond), broken)
+	fmt.Printf("  gave up after %d attempts: Ok=%v err=%v\n", calls, r2.IsOk(), r2.Err)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestRetryResultSucceedsLater checks that an op failing twice and then
+// succeeding yields Ok with its value after exactly three calls.
+func TestRetryResultSucceedsLater(t *testing.T) {
+	calls := 0
+	r := RetryResult(context.Background(), 5, ConstantBackoff(time.Millisecond), func(ctx context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errors.New("transient")
+		}
+		return 42, nil
+	})
+
+	if !r.IsOk() || r.Value != 42 {
+		t.Errorf("got %+v; want Ok(42)", r)
+	}
+	if calls != 3 {
+		t.Errorf("op called %d times; want 3", calls)
+	}
+}
+
+// TestRetryResultReturnsLastError checks that when every attempt fails the
+// result carries the error of the final attempt.
+func TestRetryResultReturnsLastError(t *testing.T) {
+	calls := 0
+	r := RetryResult(context.Background(), 3, ConstantBackoff(time.Millisecond), func(ctx context.Context) (string, error) {
+		calls++
+		return "", fmt.Errorf("failure #%d", calls)
+	})
+
+	if r.IsOk() {
+		t.Fatalf("got Ok(%q); want Err", r.Value)
+	}
+	if calls != 3 {
+		t.Errorf("op called %d times; want 3", calls)
+	}
+	if got, want := r.Err.Error(), "failure #3"; got != want {
+		t.Errorf("err = %q; want %q", got, want)
+	}
+}
+
+// TestRetryResultCancelled checks that cancelling ctx during a backoff stops
+// retrying and returns an error matching both ctx.Err() and the op's error.
+func TestRetryResultCancelled(t *testing.T) {
+	errDown := errors.New("down")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	r := RetryResult(ctx, 10, ConstantBackoff(time.Hour), func(ctx context.Context) (int, error) {
+		calls++
+		return 0, errDown
+	})
+
+	if calls != 1 {
+		t.Errorf("op called %d times; want 1 (cancelled during the first backoff)", calls)
+	}
+	if !errors.Is(r.Err, context.DeadlineExceeded) || !errors.Is(r.Err, errDown) {
+		t.Errorf("err = %v; want DeadlineExceeded wrapping %v", r.Err, errDown)
+	}
+}
+
+// TestExponentialBackoff checks doubling and the cap.
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	want := []time.Duration{10, 20, 40, 50, 50}
+	for i, w := range want {
+		if got := b(i + 1); got != w*time.Millisecond {
+			t.Errorf("attempt %d: got %v; want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+	if got := b(100); got != 50*time.Millisecond {
+		t.Errorf("attempt 100 (shift overflow): got %v; want cap", got)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// ── SortedSet[T] — ordered set on a skip list ────────────────────────────────
+// Set[T] answers "is x in the set?" in O(1) but knows nothing about order.
+// SortedSet keeps its elements sorted by a caller-supplied comparison, so it
+// also answers Min, Max and "everything between lo and hi".
+//
+// It is backed by a skip list: a sorted linked list where each node also
+// appears in a random number of express lanes (levels). A node reaches level
+// k+1 with probability 1/2, so searches skip about half the remaining nodes
+// per level and Add/Remove/Contains run in expected O(log n) — with far less
+// code than a red-black or AVL tree.
+//
+// T need not be comparable: equality is cmp(a, b) == 0.
+
+const maxSkipLevel = 32 // enough for 2^32 elements at p = 1/2
+
+type skipNode[T any] struct {
+	val  T
+	next []*skipNode[T] // next[i] is the successor on level i
+}
+
+// SortedSet is an ordered set of T. It is not safe for concurrent use.
+type SortedSet[T any] struct {
+	cmp   func(a, b T) int
+	head  *skipNode[T] // sentinel; head.next[i] is the first node on level i
+	level int          // number of levels in use (≥ 1)
+	n     int
+}
+
+// NewSortedSet returns an empty set ordered by cmp, which must return a
+// negative number, zero or a positive number as a < b, a == b or a > b
+// (cmp.Compare has this shape).
+func NewSortedSet[T any](cmp func(a, b T) int) *SortedSet[T] {
+	return &SortedSet[T]{
+		cmp:   cmp,
+		head:  &skipNode[T]{next: make([]*skipNode[T], maxSkipLevel)},
+		level: 1,
+	}
+}
+
+// randomLevel returns a level in [1, maxSkipLevel] with P(level > k) = 2^-k.
+func randomLevel() int {
+	return min(1+bits.TrailingZeros64(rand.Uint64()), maxSkipLevel)
+}
+
+// findPrev fills prev[i] with the last node on level i whose value is < v and
+// returns the level-0 successor of prev[0] (the first node ≥ v, or nil).
+func (s *SortedSet[T]) findPrev(v T, prev []*skipNode[T]) *skipNode[T] {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && s.cmp(x.next[i].val, v) < 0 {
+			x = x.next[i]
+		}
+		if prev != nil {
+			prev[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+// Add inserts v and reports whether it was not already present.
+func (s *SortedSet[T]) Add(v T) bool {
+	var prev [maxSkipLevel]*skipNode[T]
+	if x := s.findPrev(v, prev[:]); x != nil && s.cmp(x.val, v) == 0 {
+		return false
+	}
+
+	lvl := randomLevel()
+	for i := s.level; i < lvl; i++ {
+		prev[i] = s.head // new levels start at the sentinel
+	}
+	s.level = max(s.level, lvl)
+
+	node := &skipNode[T]{val: v, next: make([]*skipNode[T], lvl)}
+	for i := 0; i < lvl; i++ {
+		node.next[i] = prev[i].next[i]
+		prev[i].next[i] = node
+	}
+	s.n++
+	return true
+}
+
+// Remove deletes v and reports whether it was present.
+func (s *SortedSet[T]) Remove(v T) bool {
+	var prev [maxSkipLevel]*skipNode[T]
+	x := s.findPrev(v, prev[:])
+	if x == nil || s.cmp(x.val, v) != 0 {
+		return false
+	}
+
+	for i := 0; i < len(x.next); i++ {
+		prev[i].next[i] = x.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.n--
+	return true
+}
+
+// Contains reports whether v is in the set.
+func (s *SortedSet[T]) Contains(v T) bool {
+	x := s.findPrev(v, nil)
+	return x != nil && s.cmp(x.val, v) == 0
+}
+
+// Min returns the smallest element, or false if the set is empty.
+func (s *SortedSet[T]) Min() (T, bool) {
+	if x := s.head.next[0]; x != nil {
+		return x.val, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Max returns the largest element, or false if the set is empty.
+func (s *SortedSet[T]) Max() (T, bool) {
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.next[i] != nil {
+			x = x.next[i]
+		}
+	}
+	if x == s.head {
+		var zero T
+		return zero, false
+	}
+	return x.val, true
+}
+
+// Range returns the elements v with lo ≤ v ≤ hi, in ascending order.
+func (s *SortedSet[T]) Range(lo, hi T) []T {
+	var out []T
+	for x := s.findPrev(lo, nil); x != nil && s.cmp(x.val, hi) <= 0; x = x.next[0] {
+		out = append(out, x.val)
+	}
+	return out
+}
+
+// Len returns the number of elements.
+func (s *SortedSet[T]) Len() int { return s.n }
+
+func demoSortedSet() {
+	s := NewSortedSet(cmp.Compare[int])
+	for _, v := range []int{42, 7, 19, 3, 88, 19, 56} {
+		s.Add(v) // 19 is added twice; the second Add is a no-op
+	}
+	lo, _ := s.Min()
+	hi, _ := s.Max()
+	fmt.Printf("  Len=%d  Min=%d  Max=%d\n", s.Len(), lo, hi)
+	fmt.Println("  Range(10, 60) →", s.Range(10, 60))
+
+	s.Remove(19)
+	fmt.Println("  after Remove(19), Range(0, 100) →", s.Range(0, 100))
+
+	// T need not be comparable: order people by age with a custom cmp.
+	type person struct {
+		name string
+		age  int
+	}
+	byAge := NewSortedSet(func(a, b person) int { return cmp.Compare(a.age, b.age) })
+	byAge.Add(person{"Ana", 34})
+	byAge.Add(person{"Luis", 28})
+	byAge.Add(person{"Eva", 41})
+	youngest, _ := byAge.Min()
+	fmt.Printf("  youngest by custom cmp: %s (%d)\n", youngest.name, youngest.age)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestSortedSetRange checks that Range returns exactly the elements within
+// [lo, hi], in ascending order, for bounds inside, outside and between
+// elements.
+func TestSortedSetRange(t *testing.T) {
+	s := NewSortedSet(cmp.Compare[int])
+	for _, v := range []int{50, 10, 40, 20, 30, 10} {
+		s.Add(v)
+	}
+
+	tests := []struct {
+		lo, hi int
+		want   []int
+	}{
+		{0, 100, []int{10, 20, 30, 40, 50}},
+		{20, 40, []int{20, 30, 40}},
+		{15, 35, []int{20, 30}},
+		{31, 39, nil},
+		{60, 70, nil},
+		{40, 20, nil}, // empty interval
+	}
+	for _, tc := range tests {
+		if got := s.Range(tc.lo, tc.hi); !slices.Equal(got, tc.want) {
+			t.Errorf("Range(%d, %d) = %v; want %v", tc.lo, tc.hi, got, tc.want)
+		}
+	}
+}
+
+// TestSortedSetRandomOps applies random Add/Remove operations and checks the
+// set against a sorted reference slice after every step.
+func TestSortedSetRandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	s := NewSortedSet(cmp.Compare[int])
+	var ref []int // sorted, unique
+
+	for i := 0; i < 2000; i++ {
+		v := r.IntN(200)
+		idx, found := slices.BinarySearch(ref, v)
+
+		if r.IntN(3) < 2 {
+			if got := s.Add(v); got == found {
+				t.Fatalf("step %d: Add(%d) = %v; want %v", i, v, got, !found)
+			}
+			if !found {
+				ref = slices.Insert(ref, idx, v)
+			}
+		} else {
+			if got := s.Remove(v); got != found {
+				t.Fatalf("step %d: Remove(%d) = %v; want %v", i, v, got, found)
+			}
+			if found {
+				ref = slices.Delete(ref, idx, idx+1)
+			}
+		}
+
+		if s.Len() != len(ref) {
+			t.Fatalf("step %d: Len() = %d; want %d", i, s.Len(), len(ref))
+		}
+		if s.Contains(v) != slices.Contains(ref, v) {
+			t.Fatalf("step %d: Contains(%d) = %v", i, v, s.Contains(v))
+		}
+	}
+
+	if got := s.Range(0, 200); !slices.Equal(got, ref) {
+		t.Fatalf("Range(0, 200) = %v; want %v", got, ref)
+	}
+	if len(ref) > 0 {
+		if lo, _ := s.Min(); lo != ref[0] {
+			t.Errorf("Min() = %d; want %d", lo, ref[0])
+		}
+		if hi, _ := s.Max(); hi != ref[len(ref)-1] {
+			t.Errorf("Max() = %d; want %d", hi, ref[len(ref)-1])
+		}
+	}
+}
+
+// TestSortedSetEmpty checks Min/Max on an empty set and after removing the
+// last element.
+func TestSortedSetEmpty(t *testing.T) {
+	s := NewSortedSet(cmp.Compare[string])
+	if _, ok := s.Min(); ok {
+		t.Error("Min() on empty set reported ok")
+	}
+
+	s.Add("x")
+	s.Remove("x")
+	if _, ok := s.Max(); ok {
+		t.Error("Max() after removing the last element reported ok")
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", s.Len())
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ── TieredCache[K, V] — L1 in memory + pluggable L2 ─────────────────────────
+// The usual production stack: a small LRU in the process (L1, nanoseconds,
+// per instance) in front of a shared cache such as Redis (L2, a network hop,
+// shared by every instance) in front of the source of truth (the loader).
+//
+//	Get:  L1 hit → done
+//	      L2 hit → promote to L1
+//	      miss   → loader, then write both tiers
+//
+// L2 is an optimisation, not the source of truth: an L2 failure during Get
+// falls through to the loader instead of failing the call.
+
+// L2 is the second tier of a TieredCache, typically a client for a shared
+// cache. Get reports a miss with ok == false and a nil error.
+type L2[K comparable, V any] interface {
+	Get(ctx context.Context, k K) (v V, ok bool, err error)
+	Set(ctx context.Context, k K, v V) error
+}
+
+// TieredCache is an LRU of at most capacity entries backed by an L2. It is
+// safe for concurrent use if l2 is.
+type TieredCache[K comparable, V any] struct {
+	l2 L2[K, V]
+
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // of *lruEntry[K, V], most recently used first
+	entries  map[K]*list.Element
+	stats    TieredStats
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// TieredStats counts where Get calls were answered from.
+type TieredStats struct {
+	L1Hits, L2Hits, Loads int
+	L2Errors              int // failed L2 reads and writes tolerated by Get
+}
+
+// NewTieredCache returns a TieredCache whose L1 holds at most capacity
+// (>= 1) entries, evicting the least recently used.
+func NewTieredCache[K comparable, V any](capacity int, l2 L2[K, V]) *TieredCache[K, V] {
+	return &TieredCache[K, V]{
+		l2:       l2,
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value for k from L1, else from L2 (copying it into L1),
+// else from loader, whose result is written to both tiers. A loader error is
+// returned and nothing is cached.
+//
+// Unlike Cache.GetOrLoad, concurrent misses on one key each call loader.
+func (c *TieredCache[K, V]) Get(ctx context.Context, k K, loader func(context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	if v, ok := c.getLocked(k); ok {
+		c.stats.L1Hits++
+		c.mu.Unlock()
+		return v, nil
+	}
+	c.mu.Unlock()
+
+	v, ok, err := c.l2.Get(ctx, k)
+	if err == nil && ok {
+		c.mu.Lock()
+		c.stats.L2Hits++
+		c.addLocked(k, v)
+		c.mu.Unlock()
+		return v, nil
+	}
+
+	v, lerr := loader(ctx)
+	if lerr != nil {
+		c.mu.Lock()
+		c.stats.L2Errors += errCount(err)
+		c.mu.Unlock()
+		var zero V
+		return zero, lerr
+	}
+	serr := c.l2.Set(ctx, k, v)
+
+	c.mu.Lock()
+	c.stats.Loads++
+	c.stats.L2Errors += errCount(err) + errCount(serr)
+	c.addLocked(k, v)
+	c.mu.Unlock()
+	return v, nil
+}
+
+// Set writes v to both tiers. L1 is updated even if the L2 write fails;
+// that error is returned.
+func (c *TieredCache[K, V]) Set(ctx context.Context, k K, v V) error {
+	c.mu.Lock()
+	c.addLocked(k, v)
+	c.mu.Unlock()
+	return c.l2.Set(ctx, k, v)
+}
+
+// Len returns the number of entries in L1.
+func (c *TieredCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Stats returns the hit, load and L2 error counters.
+func (c *TieredCache[K, V]) Stats() TieredStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// getLocked looks k up in L1 and marks it most recently used. c.mu must be
+// held.
+func (c *TieredCache[K, V]) getLocked(k K) (V, bool) {
+	el, ok := c.entries[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry[K, V]).value, true
+}
+
+// addLocked stores k in L1 as most recently used, evicting the least
+// recently used entry if L1 is full. c.mu must be held.
+func (c *TieredCache[K, V]) addLocked(k K, v V) {
+	if el, ok := c.entries[k]; ok {
+		el.Value.(*lruEntry[K, V]).value = v
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[k] = c.order.PushFront(&lruEntry[K, V]{key: k, value: v})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+func errCount(err error) int {
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// mapL2 is an in-memory stand-in for a shared cache, for the demo.
+type mapL2[K comparable, V any] struct {
+	mu   sync.Mutex
+	m    map[K]V
+	down bool // simulate an outage
+}
+
+func (s *mapL2[K, V]) Get(ctx context.Context, k K) (V, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		var zero V
+		return zero, false, errors.New("l2 unavailable")
+	}
+	v, ok := s.m[k]
+	return v, ok, nil
+}
+
+func (s *mapL2[K, V]) Set(ctx context.Context, k K, v V) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		return errors.New("l2 unavailable")
+	}
+	s.m[k] = v
+	return nil
+}
+
+func demoTiered() {
+	shared := &mapL2[int, string]{m: map[int]string{}}
+	loadUser := func(id int) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("user-%d", id), nil
+		}
+	}
+
+	// Two instances of a service share the L2.
+	a := NewTieredCache[int, string](2, shared)
+	b := NewTieredCache[int, string](2, shared)
+
+	a.Get(context.Background(), 1, loadUser(1)) // miss everywhere → load
+	a.Get(context.Background(), 1, loadUser(1)) // L1 hit
+	b.Get(context.Background(), 1, loadUser(1)) // L2 hit, promoted into b's L1
+	fmt.Printf("  instance a: %+v\n", a.Stats())
+	fmt.Printf("  instance b: %+v\n", b.Stats())
+
+	// L1 holds 2 entries: a third evicts the least recently used.
+	a.Get(context.Background(), 2, loadUser(2))
+	a.Get(context.Background(), 3, loadUser(3))
+	fmt.Printf("  a.Len() after 3 keys with capacity 2: %d\n", a.Len())
+	a.Get(context.Background(), 1, loadUser(1)) // evicted from L1, still in L2
+	fmt.Printf("  Get(1) after eviction: %+v\n", a.Stats())
+
+	shared.down = true
+	v, err := b.Get(context.Background(), 4, loadUser(4))
+	fmt.Printf("  L2 down: Get(4) = %q, %v; %+v\n", v, err, b.Stats())
+}
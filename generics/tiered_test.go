@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeL2 is an L2 backed by a map that counts its calls.
+type fakeL2 struct {
+	m          map[string]int
+	gets, sets int
+}
+
+func newFakeL2() *fakeL2 { return &fakeL2{m: map[string]int{}} }
+
+func (f *fakeL2) Get(ctx context.Context, k string) (int, bool, error) {
+	f.gets++
+	v, ok := f.m[k]
+	return v, ok, nil
+}
+
+func (f *fakeL2) Set(ctx context.Context, k string, v int) error {
+	f.sets++
+	f.m[k] = v
+	return nil
+}
+
+// countingLoader returns a loader yielding v and the counter it bumps.
+func countingLoader(v int) (func(context.Context) (int, error), *int) {
+	calls := new(int)
+	return func(ctx context.Context) (int, error) {
+		*calls++
+		return v, nil
+	}, calls
+}
+
+// TestTieredCacheL1HitAvoidsL2 checks that once a key is in L1, Get answers
+// from it without calling L2 or the loader.
+func TestTieredCacheL1HitAvoidsL2(t *testing.T) {
+	l2 := newFakeL2()
+	c := NewTieredCache[string, int](4, l2)
+	if err := c.Set(context.Background(), "a", 1); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	l2.gets = 0
+
+	loader, calls := countingLoader(99)
+	for i := 0; i < 3; i++ {
+		v, err := c.Get(context.Background(), "a", loader)
+		if err != nil || v != 1 {
+			t.Fatalf("Get = %v, %v; want 1, nil", v, err)
+		}
+	}
+	if l2.gets != 0 || *calls != 0 {
+		t.Errorf("L2 gets, loader calls = %d, %d; want 0, 0", l2.gets, *calls)
+	}
+	if got := c.Stats().L1Hits; got != 3 {
+		t.Errorf("L1Hits = %d; want 3", got)
+	}
+}
+
+// TestTieredCacheL2HitPromotes checks that a value found only in L2 is
+// returned without loading and copied into L1, so the next Get skips L2.
+func TestTieredCacheL2HitPromotes(t *testing.T) {
+	l2 := newFakeL2()
+	l2.m["a"] = 7
+	c := NewTieredCache[string, int](4, l2)
+
+	loader, calls := countingLoader(99)
+	for i := 0; i < 2; i++ {
+		v, err := c.Get(context.Background(), "a", loader)
+		if err != nil || v != 7 {
+			t.Fatalf("Get = %v, %v; want 7, nil", v, err)
+		}
+	}
+	if l2.gets != 1 || *calls != 0 {
+		t.Errorf("L2 gets, loader calls = %d, %d; want 1, 0", l2.gets, *calls)
+	}
+	if s := c.Stats(); s.L2Hits != 1 || s.L1Hits != 1 {
+		t.Errorf("stats = %+v; want 1 L2 hit then 1 L1 hit", s)
+	}
+}
+
+// TestTieredCacheLoadPopulatesBoth checks that a miss in both tiers calls
+// the loader once and writes its result to L1 and L2, and that a loader
+// error is returned and cached nowhere.
+func TestTieredCacheLoadPopulatesBoth(t *testing.T) {
+	l2 := newFakeL2()
+	c := NewTieredCache[string, int](4, l2)
+
+	loader, calls := countingLoader(5)
+	if v, err := c.Get(context.Background(), "a", loader); err != nil || v != 5 {
+		t.Fatalf("Get = %v, %v; want 5, nil", v, err)
+	}
+	if got, ok := l2.m["a"]; !ok || got != 5 {
+		t.Errorf("L2 holds %v, %v; want 5, true", got, ok)
+	}
+	if v, _ := c.Get(context.Background(), "a", loader); v != 5 || *calls != 1 || l2.gets != 1 {
+		t.Errorf("second Get = %v with %d loads, %d L2 gets; want 5 from L1", v, *calls, l2.gets)
+	}
+
+	boom := errors.New("boom")
+	_, err := c.Get(context.Background(), "b", func(ctx context.Context) (int, error) { return 0, boom })
+	if !errors.Is(err, boom) {
+		t.Fatalf("Get with failing loader = %v; want boom", err)
+	}
+	if _, ok := l2.m["b"]; ok || c.Len() != 1 {
+		t.Errorf("failed load was cached: L2 has b = %v, L1 len = %d", ok, c.Len())
+	}
+}
+
+// TestTieredCacheEvictsLRU checks that L1 keeps at most capacity entries and
+// evicts the least recently used one.
+func TestTieredCacheEvictsLRU(t *testing.T) {
+	l2 := newFakeL2()
+	c := NewTieredCache[string, int](2, l2)
+	ctx := context.Background()
+
+	c.Set(ctx, "a", 1)
+	c.Set(ctx, "b", 2)
+	loader, _ := countingLoader(0)
+	c.Get(ctx, "a", loader) // a is now more recent than b
+	c.Set(ctx, "c", 3)      // evicts b
+
+	l2.gets = 0
+	c.Get(ctx, "a", loader)
+	c.Get(ctx, "c", loader)
+	if l2.gets != 0 {
+		t.Errorf("a and c went to L2 %d times; want 0 (both in L1)", l2.gets)
+	}
+	c.Get(ctx, "b", loader)
+	if l2.gets != 1 || c.Len() != 2 {
+		t.Errorf("L2 gets, L1 len = %d, %d; want 1 (b was evicted), 2", l2.gets, c.Len())
+	}
+}
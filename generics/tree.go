@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"iter"
+)
+
+// ── Tree[T] — recursive generic type + range-over-func traversals ────────────
+// A node refers to children of its own instantiated type (*Tree[T]), which Go
+// allows for generic structs. DFS and BFS return iter.Seq[T], so callers walk
+// the tree with a plain for-range and can stop early with break.
+
+// Tree is a node with a value and an ordered list of children.
+type Tree[T any] struct {
+	Value    T
+	Children []*Tree[T]
+}
+
+// NewTree returns a single-node tree.
+func NewTree[T any](v T) *Tree[T] {
+	return &Tree[T]{Value: v}
+}
+
+// AddChild appends a child holding v and returns it, so subtrees can be
+// built by chaining.
+func (t *Tree[T]) AddChild(v T) *Tree[T] {
+	child := NewTree(v)
+	t.Children = append(t.Children, child)
+	return child
+}
+
+// DFS yields the values in depth-first pre-order: a node, then each of its
+// subtrees left to right.
+func (t *Tree[T]) DFS() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.dfs(yield)
+	}
+}
+
+// dfs reports whether the walk should continue (yield never returned false).
+func (t *Tree[T]) dfs(yield func(T) bool) bool {
+	if !yield(t.Value) {
+		return false
+	}
+	for _, c := range t.Children {
+		if !c.dfs(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// BFS yields the values level by level, left to right within a level.
+func (t *Tree[T]) BFS() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		queue := []*Tree[T]{t}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if !yield(n.Value) {
+				return
+			}
+			queue = append(queue, n.Children...)
+		}
+	}
+}
+
+func demoTree() {
+	//        A
+	//      / | \
+	//     B  C  D
+	//    / \    |
+	//   E   F   G
+	root := NewTree("A")
+	b := root.AddChild("B")
+	root.AddChild("C")
+	d := root.AddChild("D")
+	b.AddChild("E")
+	b.AddChild("F")
+	d.AddChild("G")
+
+	fmt.Print("  DFS (pre-order): ")
+	for v := range root.DFS() {
+		fmt.Print(v, " ")
+	}
+	fmt.Print("\n  BFS (by level):  ")
+	for v := range root.BFS() {
+		fmt.Print(v, " ")
+	}
+
+	fmt.Print("\n  BFS until \"D\":   ")
+	for v := range root.BFS() {
+		fmt.Print(v, " ")
+		if v == "D" {
+			break // the iterator stops; E, F, G are never visited
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// buildTestTree returns
+//
+//	     1
+//	   / | \
+//	  2  3  4
+//	 / \     \
+//	5   6     7
+//	          |
+//	          8
+func buildTestTree() *Tree[int] {
+	root := NewTree(1)
+	n2 := root.AddChild(2)
+	root.AddChild(3)
+	n4 := root.AddChild(4)
+	n2.AddChild(5)
+	n2.AddChild(6)
+	n4.AddChild(7).AddChild(8)
+	return root
+}
+
+// TestTreeTraversalOrder checks the full DFS and BFS visit orders.
+func TestTreeTraversalOrder(t *testing.T) {
+	root := buildTestTree()
+
+	if got, want := slices.Collect(root.DFS()), []int{1, 2, 5, 6, 3, 4, 7, 8}; !slices.Equal(got, want) {
+		t.Errorf("DFS = %v; want %v", got, want)
+	}
+	if got, want := slices.Collect(root.BFS()), []int{1, 2, 3, 4, 5, 6, 7, 8}; !slices.Equal(got, want) {
+		t.Errorf("BFS = %v; want %v", got, want)
+	}
+}
+
+// TestTreeEarlyBreak checks that breaking out of the range loop stops both
+// traversals at once, including from deep inside DFS recursion.
+func TestTreeEarlyBreak(t *testing.T) {
+	root := buildTestTree()
+
+	tests := []struct {
+		name string
+		walk func() []int
+		want []int
+	}{
+		{"DFS", func() []int {
+			var got []int
+			for v := range root.DFS() {
+				got = append(got, v)
+				if v == 6 { // inside the subtree of 2
+					break
+				}
+			}
+			return got
+		}, []int{1, 2, 5, 6}},
+		{"BFS", func() []int {
+			var got []int
+			for v := range root.BFS() {
+				got = append(got, v)
+				if v == 3 {
+					break
+				}
+			}
+			return got
+		}, []int{1, 2, 3}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// A traversal that kept calling yield after break would panic.
+			if got := tc.walk(); !slices.Equal(got, tc.want) {
+				t.Errorf("got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
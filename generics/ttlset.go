@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ── TTLSet[T] — deduplication within a time window ───────────────────────────
+// At-least-once delivery (queues, webhooks, retries) means the same message
+// can arrive twice. A TTLSet remembers the IDs seen in the last ttl so the
+// duplicate is dropped, while old IDs expire and memory stays bounded.
+
+// TTLSet is a set whose members expire ttl after they were added. It is safe
+// for concurrent use.
+type TTLSet[T comparable] struct {
+	ttl time.Duration
+	now func() time.Time // overridable in tests
+
+	mu        sync.Mutex
+	expiresAt map[T]time.Time
+	nextSweep time.Time
+}
+
+// NewTTLSet returns an empty set whose entries live for ttl.
+func NewTTLSet[T comparable](ttl time.Duration) *TTLSet[T] {
+	return &TTLSet[T]{ttl: ttl, now: time.Now, expiresAt: make(map[T]time.Time)}
+}
+
+// AddIfAbsent adds v and returns true, unless v was already added within the
+// last ttl, in which case it returns false and leaves the expiry unchanged:
+// the window starts at the first sighting, not the latest.
+func (s *TTLSet[T]) AddIfAbsent(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+
+	if exp, ok := s.expiresAt[v]; ok && now.Before(exp) {
+		return false
+	}
+	s.expiresAt[v] = now.Add(s.ttl)
+	return true
+}
+
+// Len returns the number of entries still held, including any that expired
+// but have not been swept yet.
+func (s *TTLSet[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.expiresAt)
+}
+
+// sweepLocked drops expired entries. Expiry is lazy: there is no background
+// goroutine, and a full scan runs at most once per ttl, so the map holds at
+// most about two windows' worth of entries.
+func (s *TTLSet[T]) sweepLocked(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for v, exp := range s.expiresAt {
+		if !now.Before(exp) {
+			delete(s.expiresAt, v)
+		}
+	}
+	s.nextSweep = now.Add(s.ttl)
+}
+
+func demoTTLSet() {
+	seen := NewTTLSet[string](30 * time.Millisecond)
+
+	deliveries := []string{"msg-1", "msg-2", "msg-1"} // msg-1 redelivered
+	for _, id := range deliveries {
+		fmt.Printf("  %s → process=%v\n", id, seen.AddIfAbsent(id))
+	}
+
+	time.Sleep(40 * time.Millisecond) // the window passes
+	fmt.Printf("  after the window: msg-1 → process=%v\n", seen.AddIfAbsent("msg-1"))
+}
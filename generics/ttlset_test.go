@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestTTLSet(ttl time.Duration) (*TTLSet[string], *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	s := NewTTLSet[string](ttl)
+	s.now = clock.now
+	return s, clock
+}
+
+// TestTTLSetDedupesWithinWindow checks that a value is rejected while its
+// window is open and accepted again once it has expired.
+func TestTTLSetDedupesWithinWindow(t *testing.T) {
+	s, clock := newTestTTLSet(time.Minute)
+
+	if !s.AddIfAbsent("a") {
+		t.Fatal("first AddIfAbsent(a) = false; want true")
+	}
+	clock.advance(59 * time.Second)
+	if s.AddIfAbsent("a") {
+		t.Error("AddIfAbsent(a) within the window = true; want false")
+	}
+
+	clock.advance(time.Second) // exactly ttl after the first add
+	if !s.AddIfAbsent("a") {
+		t.Error("AddIfAbsent(a) after expiry = false; want true")
+	}
+}
+
+// TestTTLSetWindowStartsAtFirstSighting checks that a rejected duplicate does
+// not extend the window.
+func TestTTLSetWindowStartsAtFirstSighting(t *testing.T) {
+	s, clock := newTestTTLSet(time.Minute)
+
+	s.AddIfAbsent("a")
+	clock.advance(40 * time.Second)
+	s.AddIfAbsent("a") // duplicate: must not push the expiry out
+	clock.advance(20 * time.Second)
+
+	if !s.AddIfAbsent("a") {
+		t.Error("AddIfAbsent(a) 60s after first sighting = false; want true")
+	}
+}
+
+// TestTTLSetSweepsExpired checks that expired entries are eventually removed,
+// so memory does not grow with every distinct value ever seen.
+func TestTTLSetSweepsExpired(t *testing.T) {
+	s, clock := newTestTTLSet(time.Minute)
+
+	for _, v := range []string{"a", "b", "c"} {
+		s.AddIfAbsent(v)
+	}
+	clock.advance(2 * time.Minute)
+	s.AddIfAbsent("d") // triggers the lazy sweep
+
+	if got := s.Len(); got != 1 {
+		t.Errorf("Len() = %d; want 1 (only d)", got)
+	}
+}
@@ -32,6 +32,9 @@ func main() {
 
 	section("Bounded concurrency")
 	demoBounded()
+
+	section("Structured concurrency — nursery")
+	demoNursery()
 }
 
 func section(title string) {
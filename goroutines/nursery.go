@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ── Structured concurrency: the nursery ───────────────────────────────────────
+//
+// A nursery scopes goroutines to a block of code: every child is started
+// through the nursery and Wait does not return until all of them have
+// finished. No child can outlive the scope, so leaks are impossible by
+// construction (the same idea as errgroup.Group or Trio's nurseries).
+//
+//	n, ctx := WithNursery(ctx)
+//	n.Start(fetchUser)
+//	n.Start(fetchOrders)
+//	err := n.Wait() // both children are gone when this returns
+
+// Nursery runs child goroutines that share a context. The first child to
+// return a non-nil error cancels that context so its siblings can stop early.
+type Nursery struct {
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	errOnce sync.Once
+	err     error
+}
+
+// WithNursery returns a new Nursery and the derived context handed to every
+// child. The context is cancelled when a child fails or when Wait returns.
+func WithNursery(ctx context.Context) (*Nursery, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Nursery{ctx: ctx, cancel: cancel}, ctx
+}
+
+// Start runs fn in a new child goroutine. Start must not be called after
+// Wait has returned.
+func (n *Nursery) Start(fn func(context.Context) error) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		if err := fn(n.ctx); err != nil {
+			n.errOnce.Do(func() {
+				n.err = err
+				n.cancel() // tell the siblings to give up
+			})
+		}
+	}()
+}
+
+// Wait blocks until every child has returned and reports the first error.
+// After Wait returns no child goroutine is running.
+func (n *Nursery) Wait() error {
+	n.wg.Wait()
+	n.cancel() // release context resources
+	return n.err
+}
+
+// demoNursery starts three children; one fails and the nursery cancels the
+// other two, then Wait returns the failure once everyone has exited.
+func demoNursery() {
+	n, _ := WithNursery(context.Background())
+
+	for i := 1; i <= 3; i++ {
+		id := i
+		n.Start(func(ctx context.Context) error {
+			if id == 2 {
+				time.Sleep(20 * time.Millisecond)
+				fmt.Printf("  child%d: failing\n", id)
+				return errors.New("child2: upstream unavailable")
+			}
+			select {
+			case <-time.After(time.Second):
+				fmt.Printf("  child%d: done\n", id)
+				return nil
+			case <-ctx.Done():
+				fmt.Printf("  child%d: cancelled (%v)\n", id, ctx.Err())
+				return ctx.Err()
+			}
+		})
+	}
+
+	err := n.Wait()
+	fmt.Println("  Wait returned:", err)
+}
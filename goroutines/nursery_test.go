@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestNurseryAllSucceed checks that Wait returns nil after every child ran.
+func TestNurseryAllSucceed(t *testing.T) {
+	n, _ := WithNursery(context.Background())
+
+	var ran int64
+	for i := 0; i < 10; i++ {
+		n.Start(func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&ran, 1)
+			return nil
+		})
+	}
+
+	if err := n.Wait(); err != nil {
+		t.Fatalf("Wait() = %v; want nil", err)
+	}
+	if got := atomic.LoadInt64(&ran); got != 10 {
+		t.Errorf("ran %d children; want 10", got)
+	}
+}
+
+// TestNurseryFailureCancelsSiblings checks that the first error cancels the
+// shared context and is the one returned by Wait.
+func TestNurseryFailureCancelsSiblings(t *testing.T) {
+	n, _ := WithNursery(context.Background())
+	errBoom := errors.New("boom")
+
+	var cancelled int64
+	for i := 0; i < 3; i++ {
+		n.Start(func(ctx context.Context) error {
+			select {
+			case <-ctx.Done():
+				atomic.AddInt64(&cancelled, 1)
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		})
+	}
+	n.Start(func(ctx context.Context) error { return errBoom })
+
+	start := time.Now()
+	err := n.Wait()
+
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Wait() = %v; want %v", err, errBoom)
+	}
+	if got := atomic.LoadInt64(&cancelled); got != 3 {
+		t.Errorf("%d siblings observed cancellation; want 3", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait took %s; siblings were not cancelled promptly", elapsed)
+	}
+}
+
+// TestNurseryNoLeakAfterWait checks that no child is still running once Wait
+// returns, and that the goroutine count is back to its baseline.
+func TestNurseryNoLeakAfterWait(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	n, ctx := WithNursery(context.Background())
+
+	var running int64
+	for i := 0; i < 20; i++ {
+		n.Start(func(ctx context.Context) error {
+			atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}
+
+	if err := n.Wait(); err != nil {
+		t.Fatalf("Wait() = %v; want nil", err)
+	}
+	if got := atomic.LoadInt64(&running); got != 0 {
+		t.Errorf("%d children still running after Wait", got)
+	}
+	if ctx.Err() == nil {
+		t.Error("nursery context not cancelled after Wait")
+	}
+
+	// wg.Done runs in a defer just before each goroutine exits; give the
+	// scheduler a moment to reap them.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines: before=%d after=%d; want no leak", before, after)
+	}
+}
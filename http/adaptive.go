@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── Adaptive concurrency limit (AIMD) ────────────────────────────────────────
+// A fixed concurrency limit is either too low when the backend is healthy or
+// too high when it is degraded. AdaptiveLimiter discovers the limit from the
+// outcome of each call, the way TCP finds its congestion window:
+//
+//	success, fast          limit += 1/limit   (≈ +1 per full window)
+//	error or slow          limit *= 0.5       (at most once per window)
+//
+// Latency is the signal: a backend past its capacity queues work, so its
+// latency climbs before it starts failing.
+
+// aimdDecrease is the factor the limit is multiplied by on overload.
+const aimdDecrease = 0.5
+
+// AdaptiveLimiter bounds the number of concurrent calls with a limit that
+// grows additively while calls succeed quickly and shrinks multiplicatively
+// when they fail or exceed the latency threshold. It is safe for concurrent
+// use.
+type AdaptiveLimiter struct {
+	maxLimit float64
+	slow     time.Duration
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	gen      int           // bumped on every decrease; see release
+	changed  chan struct{} // closed and replaced when a slot may have opened
+}
+
+// NewAdaptiveLimiter returns a limiter starting at initial concurrent calls,
+// never going below 1 nor above maxLimit. A call that takes longer than slow
+// counts as overload, like an error.
+func NewAdaptiveLimiter(initial, maxLimit int, slow time.Duration) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		maxLimit: float64(maxLimit),
+		slow:     slow,
+		limit:    float64(min(max(initial, 1), maxLimit)),
+		changed:  make(chan struct{}),
+	}
+}
+
+// Acquire waits for a slot and returns the function that gives it back. The
+// caller must call release exactly once, with the call's latency and error;
+// later calls are no-ops. Acquire returns ctx.Err() if ctx is done first.
+func (l *AdaptiveLimiter) Acquire(ctx context.Context) (release func(latency time.Duration, err error), err error) {
+	for {
+		l.mu.Lock()
+		if l.inFlight < l.limitLocked() {
+			l.inFlight++
+			gen := l.gen
+			l.mu.Unlock()
+
+			var once sync.Once
+			return func(latency time.Duration, err error) {
+				once.Do(func() { l.release(gen, latency, err) })
+			}, nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// release frees a slot and adapts the limit. Calls acquired before the last
+// decrease (an older gen) do not decrease it again: a burst of failures from
+// one window of calls halves the limit once, not once per call.
+func (l *AdaptiveLimiter) release(gen int, latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	if err != nil || latency > l.slow {
+		if gen == l.gen {
+			l.limit = max(l.limit*aimdDecrease, 1)
+			l.gen++
+		}
+	} else {
+		l.limit = min(l.limit+1/l.limit, l.maxLimit)
+	}
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Limit returns the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limitLocked()
+}
+
+// limitLocked is Limit for callers holding l.mu.
+func (l *AdaptiveLimiter) limitLocked() int { return int(l.limit) }
+
+func demoAdaptive() {
+	// The backend serves 4 requests at a time in 5ms; beyond that, requests
+	// queue and latency climbs with the excess.
+	var inFlight int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		time.Sleep(5 * time.Millisecond * time.Duration(max(1, n-3)))
+	}))
+	defer srv.Close()
+
+	lim := NewAdaptiveLimiter(16, 32, 15*time.Millisecond)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				release, err := lim.Acquire(context.Background())
+				if err != nil {
+					return
+				}
+				start := time.Now()
+				resp, err := client.Get(srv.URL)
+				if err == nil {
+					resp.Body.Close()
+				}
+				release(time.Since(start), err)
+			}
+		}()
+	}
+
+	// Sample the limit every 25ms until all 320 requests are done.
+	samples := []int{lim.Limit()}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	tick := time.NewTicker(25 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			samples = append(samples, lim.Limit())
+		case <-done:
+			fmt.Println("  backend capacity ≈ 4 concurrent requests; slow = 15ms")
+			fmt.Println("  limit over time:", append(samples, lim.Limit()))
+			return
+		}
+	}
+}
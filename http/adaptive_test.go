@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// limitedCall acquires a slot from l and releases it with latency and err.
+func limitedCall(t *testing.T, l *AdaptiveLimiter, latency time.Duration, err error) {
+	t.Helper()
+	release, aerr := l.Acquire(context.Background())
+	if aerr != nil {
+		t.Fatalf("Acquire: %v", aerr)
+	}
+	release(latency, err)
+}
+
+// TestAdaptiveLimiterTracksLatency feeds a latency signal that steps up and
+// back down, and checks the limit shrinks to the floor during the step and
+// grows back once calls are fast again.
+func TestAdaptiveLimiterTracksLatency(t *testing.T) {
+	const slow = 100 * time.Millisecond
+	l := NewAdaptiveLimiter(10, 20, slow)
+
+	// Healthy: additive increase, +1 per ~limit fast calls.
+	for i := 0; i < 50; i++ {
+		limitedCall(t, l, 10*time.Millisecond, nil)
+	}
+	healthy := l.Limit()
+	if healthy <= 10 {
+		t.Fatalf("limit after 50 fast calls = %d; want > 10", healthy)
+	}
+
+	// Latency step: each slow call halves the limit, down to 1.
+	want := healthy
+	for want > 1 {
+		limitedCall(t, l, 5*slow, nil)
+		want = max(want/2, 1)
+		if got := l.Limit(); got != want {
+			t.Fatalf("limit after slow call = %d; want %d", got, want)
+		}
+	}
+	if got := l.Limit(); got != 1 {
+		t.Fatalf("limit after latency step = %d; want 1", got)
+	}
+
+	// Errors count as overload too, but the floor holds.
+	limitedCall(t, l, time.Millisecond, errors.New("boom"))
+	if got := l.Limit(); got != 1 {
+		t.Errorf("limit after error at floor = %d; want 1", got)
+	}
+
+	// Recovery: fast calls grow it back, capped at the max.
+	for i := 0; i < 50; i++ {
+		limitedCall(t, l, 10*time.Millisecond, nil)
+	}
+	if got := l.Limit(); got < 8 {
+		t.Errorf("limit after recovery = %d; want it to have grown to ≥ 8", got)
+	}
+	for i := 0; i < 1000; i++ {
+		limitedCall(t, l, 10*time.Millisecond, nil)
+	}
+	if got := l.Limit(); got != 20 {
+		t.Errorf("limit after long recovery = %d; want the max, 20", got)
+	}
+}
+
+// TestAdaptiveLimiterOneDecreasePerWindow fails every call of a full window
+// concurrently in flight and checks the limit is halved once, not once per
+// failure.
+func TestAdaptiveLimiterOneDecreasePerWindow(t *testing.T) {
+	l := NewAdaptiveLimiter(8, 8, time.Second)
+
+	var releases []func(time.Duration, error)
+	for i := 0; i < 8; i++ {
+		release, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Acquire %d: %v", i, err)
+		}
+		releases = append(releases, release)
+	}
+	for _, release := range releases {
+		release(0, errors.New("backend down"))
+	}
+
+	if got := l.Limit(); got != 4 {
+		t.Errorf("limit = %d; want 4 (one halving for the whole window)", got)
+	}
+}
+
+// TestAdaptiveLimiterAcquireBlocks checks that Acquire waits while the limit
+// is reached, honours ctx, and proceeds once a slot is released.
+func TestAdaptiveLimiterAcquireBlocks(t *testing.T) {
+	l := NewAdaptiveLimiter(1, 1, time.Second)
+
+	release, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire at limit = %v; want context.DeadlineExceeded", err)
+	}
+
+	got := make(chan error, 1)
+	go func() {
+		_, err := l.Acquire(context.Background())
+		got <- err
+	}()
+	release(time.Millisecond, nil)
+	release(time.Millisecond, nil) // second call is a no-op
+
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Errorf("Acquire after release: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Acquire still blocked after release")
+	}
+}
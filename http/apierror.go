@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ── Structured error responses ───────────────────────────────────────────────
+// http.Error writes a text/plain body, so every client has to guess how to
+// parse failures. WriteError gives all demos one JSON shape:
+//
+//	{"error":{"code":"invalid_json","message":"request body is not valid JSON"}}
+//
+// and ReadAPIError turns that body back into a Go error on the client side.
+
+// APIError is the decoded form of a WriteError body. Code is a stable,
+// machine-readable identifier; Message is for humans. Status is filled in by
+// ReadAPIError from the response and is not part of the JSON.
+type APIError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("HTTP %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// errorEnvelope is the top-level JSON object: {"error": {...}}.
+type errorEnvelope struct {
+	Error *APIError `json:"error"`
+}
+
+// WriteError replies with status and a JSON error body. Like http.Error, it
+// sets nosniff and expects the caller to return without further writes.
+func WriteError(w http.ResponseWriter, status int, code, message string) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorEnvelope{Error: &APIError{Code: code, Message: message}})
+}
+
+// ReadAPIError decodes a non-2xx response into an *APIError. It returns nil
+// for 2xx responses. If the body is not a WriteError envelope (e.g. a proxy's
+// HTML page), the raw text becomes the message and Code is "unknown". The
+// body is read but not closed.
+func ReadAPIError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var env errorEnvelope
+	if err := json.Unmarshal(body, &env); err != nil || env.Error == nil {
+		return &APIError{
+			Status:  resp.StatusCode,
+			Code:    "unknown",
+			Message: strings.TrimSpace(string(body)),
+		}
+	}
+	env.Error.Status = resp.StatusCode
+	return env.Error
+}
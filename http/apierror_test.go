@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestWriteError checks status, Content-Type and the exact JSON envelope for
+// a few typical error responses.
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		status  int
+		code    string
+		message string
+	}{
+		{http.StatusBadRequest, "invalid_json", "request body is not valid JSON"},
+		{http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token"},
+		{http.StatusNotFound, "not_found", `user "42" <does not> exist`},
+		{http.StatusInternalServerError, "internal", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, tc.status, tc.code, tc.message)
+
+			if w.Code != tc.status {
+				t.Errorf("status = %d; want %d", w.Code, tc.status)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+				t.Errorf("Content-Type = %q; want application/json", ct)
+			}
+
+			var got map[string]map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body %q is not JSON: %v", w.Body.String(), err)
+			}
+			want := map[string]string{"code": tc.code, "message": tc.message}
+			if len(got) != 1 || len(got["error"]) != 2 ||
+				got["error"]["code"] != want["code"] || got["error"]["message"] != want["message"] {
+				t.Errorf("body = %v; want {error: %v}", got, want)
+			}
+		})
+	}
+}
+
+// TestReadAPIError round-trips WriteError through a real response and checks
+// the fallback for non-JSON bodies and the nil result for 2xx.
+func TestReadAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusConflict, "conflict", "version mismatch")
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := []struct {
+		path string
+		want *APIError // nil → expect no error
+	}{
+		{"/json", &APIError{Status: http.StatusConflict, Code: "conflict", Message: "version mismatch"}},
+		{"/plain", &APIError{Status: http.StatusBadGateway, Code: "unknown", Message: "bad gateway"}},
+		{"/ok", nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + tc.path)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+
+			err = ReadAPIError(resp)
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("got %v; want nil", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %T %v; want *APIError", err, err)
+			}
+			if *apiErr != *tc.want {
+				t.Errorf("got %+v; want %+v", *apiErr, *tc.want)
+			}
+		})
+	}
+}
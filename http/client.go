@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -41,7 +42,7 @@ func demoClient() {
 			time.Sleep(300 * time.Millisecond)
 			fmt.Fprintln(w, "finally done")
 		case "/error":
-			http.Error(w, "something went wrong", http.StatusInternalServerError)
+			WriteError(w, http.StatusInternalServerError, "internal", "something went wrong")
 		case "/echo":
 			io.Copy(w, r.Body)
 		}
@@ -63,6 +64,7 @@ func demoClient() {
 	fmt.Printf("  GET /fast → %d %v\n", resp.StatusCode, result)
 
 	// ── Non-2xx status — no error from client.Do ──────────────────────────────
+	// ReadAPIError (apierror.go) decodes the server's JSON error body.
 	fmt.Println("\n  Non-2xx does NOT return an error — check status explicitly:")
 	resp2, err := client.Get(srv.URL + "/error")
 	if err != nil {
@@ -70,9 +72,10 @@ func demoClient() {
 		return
 	}
 	defer resp2.Body.Close()
-	if resp2.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp2.Body)
-		fmt.Printf("  GET /error → %d %q\n", resp2.StatusCode, strings.TrimSpace(string(body)))
+	if err := ReadAPIError(resp2); err != nil {
+		var apiErr *APIError
+		errors.As(err, &apiErr)
+		fmt.Printf("  GET /error → %d code=%q message=%q\n", apiErr.Status, apiErr.Code, apiErr.Message)
 	}
 
 	// ── Context cancellation — abort in-flight request ────────────────────────
@@ -0,0 +1,38 @@
+// Package ctxkey provides typed context keys.
+//
+// context.WithValue(ctx, "request-id", id) has two problems: any package using
+// the same string reads or overwrites the value, and every reader needs an
+// unchecked type assertion. A *Key[T] fixes both: keys compare by pointer, so
+// two keys never collide even with the same name, and Value returns a T.
+package ctxkey
+
+import "context"
+
+// Key identifies a context value of type T. Create keys with New and keep
+// them in package-level variables; the zero Key is not meant to be used.
+type Key[T any] struct {
+	name string // for debugging only; identity is the pointer
+}
+
+// New returns a new, unique key. name only shows up in String.
+func New[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// WithValue returns a copy of ctx carrying v under k.
+func (k *Key[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Value returns the value stored under k and whether there was one.
+func (k *Key[T]) Value(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k).(T)
+	return v, ok
+}
+
+// String returns the key's name, e.g. for %v in log lines.
+func (k *Key[T]) String() string { return "ctxkey." + k.name }
+
+// RequestID carries the ID of the HTTP request being served; the RequestID
+// middleware sets it.
+var RequestID = New[string]("RequestID")
@@ -0,0 +1,51 @@
+package ctxkey
+
+import (
+	"context"
+	"testing"
+)
+
+// TestKeyNoCollisionWithStringKey checks that a plain string key with the
+// same name neither reads nor overwrites the typed key's value.
+func TestKeyNoCollisionWithStringKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "RequestID", "spoofed") // the anti-pattern
+
+	if v, ok := RequestID.Value(ctx); ok {
+		t.Errorf("RequestID.Value found %q stored under a string key; want nothing", v)
+	}
+
+	ctx = RequestID.WithValue(ctx, "req-1")
+	if got := ctx.Value("RequestID"); got != "spoofed" {
+		t.Errorf("string key now reads %v; want \"spoofed\" untouched", got)
+	}
+	if v, _ := RequestID.Value(ctx); v != "req-1" {
+		t.Errorf("RequestID.Value = %q; want \"req-1\"", v)
+	}
+}
+
+// TestKeysWithSameNameAreDistinct checks that keys are identified by
+// pointer, not by name.
+func TestKeysWithSameNameAreDistinct(t *testing.T) {
+	other := New[string]("RequestID")
+	ctx := other.WithValue(context.Background(), "other")
+
+	if v, ok := RequestID.Value(ctx); ok {
+		t.Errorf("RequestID.Value = %q from a different key; want nothing", v)
+	}
+}
+
+// TestKeyValueTyped checks that Value returns the stored value with its
+// static type and reports absence with the zero value.
+func TestKeyValueTyped(t *testing.T) {
+	userID := New[int]("userID")
+	ctx := userID.WithValue(context.Background(), 42)
+
+	var got int // no type assertion needed
+	got, ok := userID.Value(ctx)
+	if !ok || got != 42 {
+		t.Errorf("got (%d, %v); want (42, true)", got, ok)
+	}
+	if got, ok := userID.Value(context.Background()); ok || got != 0 {
+		t.Errorf("empty ctx: got (%d, %v); want (0, false)", got, ok)
+	}
+}
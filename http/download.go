@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// ── Bounded concurrent downloader ─────────────────────────────────────────────
+// Combines three ideas from this repo:
+//
+//   - http.Client + NewRequestWithContext  → every fetch is cancellable
+//   - a fixed set of workers on a channel  → at most N requests in flight
+//   - indexed result slots                 → output order == input order
+//
+// Each worker writes only to results[i] for the indices it receives, so the
+// slice needs no lock; wg.Wait() publishes the writes to the caller.
+
+// Result holds either a value or an error, so one failed URL does not abort
+// the whole batch. Same shape as Result[T] in the generics module.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// DownloadAll fetches every URL with at most concurrency requests in flight
+// and returns one Result per URL, in the same order as urls.
+//
+// Non-2xx responses are reported as errors. If ctx is cancelled, in-flight
+// requests are aborted and URLs that were never started get ctx.Err().
+func DownloadAll(ctx context.Context, urls []string, concurrency int, client *http.Client) []Result[[]byte] {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]Result[[]byte], len(urls))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(urls)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				body, err := fetch(ctx, client, urls[i])
+				results[i] = Result[[]byte]{Value: body, Err: err}
+			}
+		}()
+	}
+
+	// Hand out indices until done or cancelled.
+	next := 0
+feed:
+	for ; next < len(urls); next++ {
+		if ctx.Err() != nil {
+			break // select picks randomly; don't hand out work once cancelled
+		}
+		select {
+		case indices <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	// URLs never handed to a worker were skipped because of cancellation.
+	for i := next; i < len(urls); i++ {
+		results[i] = Result[[]byte]{Err: fmt.Errorf("GET %s: not started: %w", urls[i], ctx.Err())}
+	}
+	return results
+}
+
+// fetch performs a single GET bound to ctx and returns the response body.
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		io.Copy(io.Discard, resp.Body) // drain so the connection can be reused
+		return nil, fmt.Errorf("GET %s: status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func demoDownload() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprintf(w, "content of %s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	urls := []string{
+		srv.URL + "/a.txt",
+		srv.URL + "/b.txt",
+		srv.URL + "/missing",
+		srv.URL + "/c.txt",
+		srv.URL + "/d.txt",
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	start := time.Now()
+	results := DownloadAll(context.Background(), urls, 2, client)
+	fmt.Printf("  %d URLs, concurrency=2, took %v\n", len(urls), time.Since(start).Round(10*time.Millisecond))
+	for i, r := range results {
+		if r.Err != nil {
+			fmt.Printf("  [%d] error: %v\n", i, r.Err)
+			continue
+		}
+		fmt.Printf("  [%d] %q\n", i, r.Value)
+	}
+}
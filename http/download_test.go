@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDownloadAllOrder checks that every body is fetched and that results
+// come back in input order even though the fetches run concurrently.
+func TestDownloadAllOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Earlier paths respond slower, so completion order is reversed.
+		switch r.URL.Path {
+		case "/0":
+			time.Sleep(30 * time.Millisecond)
+		case "/1":
+			time.Sleep(15 * time.Millisecond)
+		}
+		fmt.Fprintf(w, "body%s", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	const n = 6
+	urls := make([]string, n)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/%d", srv.URL, i)
+	}
+
+	results := DownloadAll(context.Background(), urls, 3, srv.Client())
+
+	if len(results) != n {
+		t.Fatalf("got %d results; want %d", len(results), n)
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("results[%d].Err = %v", i, r.Err)
+			continue
+		}
+		if want := fmt.Sprintf("body/%d", i); string(r.Value) != want {
+			t.Errorf("results[%d] = %q; want %q", i, r.Value, want)
+		}
+	}
+}
+
+// TestDownloadAllStatusError checks that a non-2xx response is reported as an
+// error for that URL only.
+func TestDownloadAllStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer srv.Close()
+
+	results := DownloadAll(context.Background(),
+		[]string{srv.URL + "/ok", srv.URL + "/missing"}, 2, srv.Client())
+
+	if results[0].Err != nil || string(results[0].Value) != "ok" {
+		t.Errorf("results[0] = (%q, %v); want (\"ok\", nil)", results[0].Value, results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Error("results[1].Err = nil; want a 404 error")
+	}
+}
+
+// TestDownloadAllCancel cancels the context while the first fetch is in
+// flight and checks that the pending URLs are never requested.
+func TestDownloadAllCancel(t *testing.T) {
+	var hits int64
+	started := make(chan struct{}, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done() // hang until the client gives up
+	}))
+	defer srv.Close()
+
+	urls := make([]string, 5)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/%d", srv.URL, i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan []Result[[]byte])
+	go func() { done <- DownloadAll(ctx, urls, 1, srv.Client()) }()
+
+	var results []Result[[]byte]
+	select {
+	case results = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("DownloadAll did not return after cancellation")
+	}
+
+	for i, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("results[%d].Err = %v; want context.Canceled", i, r.Err)
+		}
+	}
+	if got := atomic.LoadInt64(&hits); got != 1 {
+		t.Errorf("server saw %d requests; want 1 (pending fetches must not start)", got)
+	}
+}
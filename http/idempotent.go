@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// ── Idempotency keys ─────────────────────────────────────────────────────────
+// Retrying a POST is unsafe in general: if the first attempt reached the
+// server but the response was lost, a retry charges the card twice. The fix
+// is an Idempotency-Key header, generated once per logical request and sent
+// unchanged on every attempt; a compatible server remembers the keys it has
+// processed and answers a repeat with the stored result instead of redoing
+// the work.
+
+// IdempotencyKeyHeader is the header carrying the key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotentRequest returns a request with a freshly generated
+// Idempotency-Key and a GetBody that replays body, so RetryTransport can
+// resend it. The key lives in the request's headers, which every retry
+// clones, so all attempts carry the same key.
+func NewIdempotentRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	// bytes.Reader makes http.NewRequest set ContentLength and GetBody.
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req, nil
+}
+
+// newIdempotencyKey returns a random UUID (version 4).
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("idempotency key: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+func demoIdempotent() {
+	// A payments backend that processes each key once. The first attempt of
+	// every key "loses" its response (503 after doing the work), as a
+	// timeout between server and client would.
+	var mu sync.Mutex
+	done := map[string]string{} // key → stored response
+	charges := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if resp, ok := done[key]; ok && key != "" {
+			fmt.Fprint(w, resp) // repeat: replay the stored result
+			return
+		}
+		charges++
+		done[key] = fmt.Sprintf("charged %s", body)
+		w.WriteHeader(http.StatusServiceUnavailable) // work done, response lost
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Timeout:   5 * time.Second,
+		Transport: &RetryTransport{MaxRetries: 2, Backoff: time.Millisecond},
+	}
+
+	req, err := NewIdempotentRequest(context.Background(), http.MethodPost, srv.URL, []byte(`{"amount":100}`))
+	if err != nil {
+		fmt.Println("  error:", err)
+		return
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Println("  error:", err)
+		return
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	fmt.Printf("  key %s → %d %q\n", req.Header.Get(IdempotencyKeyHeader), resp.StatusCode, b)
+	fmt.Printf("  charges applied by the backend: %d (the retry was deduplicated)\n", charges)
+}
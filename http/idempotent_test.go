@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestIdempotentRequestRetriesWithSameKey fails the first two attempts of a
+// POST and checks that the server saw three attempts, all with the same
+// non-empty Idempotency-Key and the same body, and that a second request
+// gets a different key.
+func TestIdempotentRequestRetriesWithSameKey(t *testing.T) {
+	type attempt struct{ key, body string }
+	var mu sync.Mutex
+	var attempts []attempt
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		attempts = append(attempts, attempt{r.Header.Get(IdempotencyKeyHeader), string(body)})
+		n := len(attempts)
+		mu.Unlock()
+		if n <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond}}
+	const payload = `{"amount":100}`
+	req, err := NewIdempotentRequest(context.Background(), http.MethodPost, srv.URL, []byte(payload))
+	if err != nil {
+		t.Fatalf("NewIdempotentRequest: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d; want 200 after retries", resp.StatusCode)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != 3 {
+		t.Fatalf("server saw %d attempts; want 3", len(attempts))
+	}
+	key := req.Header.Get(IdempotencyKeyHeader)
+	if key == "" {
+		t.Fatal("request has no Idempotency-Key")
+	}
+	for i, a := range attempts {
+		if a.key != key || a.body != payload {
+			t.Errorf("attempt %d = key %q, body %q; want key %q, body %q", i, a.key, a.body, key, payload)
+		}
+	}
+
+	other, err := NewIdempotentRequest(context.Background(), http.MethodPost, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("NewIdempotentRequest: %v", err)
+	}
+	if got := other.Header.Get(IdempotencyKeyHeader); got == key {
+		t.Errorf("second request reused key %q; want a fresh one", got)
+	}
+}
@@ -17,11 +17,26 @@ func main() {
 	section("Client — custom client, timeout, status codes, context cancellation")
 	demoClient()
 
+	section("Retries — RetryTransport with a shared RetryBudget")
+	demoRetry()
+
+	section("Idempotency keys — safe POST retries")
+	demoIdempotent()
+
+	section("Adaptive concurrency — AIMD limit driven by latency")
+	demoAdaptive()
+
 	section("Graceful shutdown — drain in-flight requests before stopping")
 	demoShutdown()
 
+	section("Hot reload — swap the handler without restarting the listener")
+	demoReload()
+
 	section("httptest — NewRecorder (unit) vs NewServer (integration)")
 	demoRecorder()
+
+	section("Downloader — bounded concurrency, ordered results, cancellation")
+	demoDownload()
 }
 
 func section(title string) {
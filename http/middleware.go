@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"time"
+
+	"httpdemos/ctxkey"
 )
 
 // ── Middleware signature ──────────────────────────────────────────────────────
@@ -42,6 +47,35 @@ func Logger(next http.Handler) http.Handler {
 	})
 }
 
+// RequestID tags every request with an ID: the incoming X-Request-ID header
+// if the client (or a proxy) sent one, otherwise a random one. The ID is
+// echoed in the response header and stored in the request context under the
+// typed key ctxkey.RequestID; read it with RequestIDFrom.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" || len(id) > 128 { // don't trust unbounded client input
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", id)
+		next.ServeHTTP(w, r.WithContext(ctxkey.RequestID.WithValue(r.Context(), id)))
+	})
+}
+
+// RequestIDFrom returns the ID set by the RequestID middleware, or "" if the
+// request did not go through it.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctxkey.RequestID.Value(ctx)
+	return id
+}
+
+// newRequestID returns 16 random hex characters.
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:]) // never fails on supported platforms
+	return hex.EncodeToString(b[:])
+}
+
 // Auth requires a valid Bearer token in the Authorization header.
 // Configured via closure — the token is captured at construction time.
 func Auth(validToken string) func(http.Handler) http.Handler {
@@ -49,7 +83,7 @@ func Auth(validToken string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
 			if got != validToken {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				WriteError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token")
 				return // do NOT call next
 			}
 			next.ServeHTTP(w, r)
@@ -64,13 +98,61 @@ func Recovery(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				fmt.Printf("  [recovery] caught panic: %v\n", err)
-				http.Error(w, "internal server error", http.StatusInternalServerError)
+				WriteError(w, http.StatusInternalServerError, "internal", "internal server error")
 			}
 		}()
 		next.ServeHTTP(w, r)
 	})
 }
 
+// DeadlineBudget shrinks the request's deadline so downstream work can use
+// only a fraction of the time left, reserving the rest for encoding the
+// response and cleanup. With 10s remaining and fraction 0.8, handlers and
+// every outgoing call made with r.Context() see a deadline 8s away.
+//
+// Requests without a deadline, and fractions outside (0, 1), pass through
+// unchanged.
+func DeadlineBudget(fraction float64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deadline, ok := r.Context().Deadline()
+			if !ok || fraction <= 0 || fraction >= 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			remaining := time.Until(deadline)
+			ctx, cancel := context.WithDeadline(r.Context(),
+				time.Now().Add(time.Duration(fraction*float64(remaining))))
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// LimitInFlight admits at most max requests at a time. The semaphore is a
+// buffered channel: a request takes a slot by sending into it and frees it
+// by receiving, in a defer so a panicking handler releases it too. When all
+// slots are taken the request is rejected at once with 503 and Retry-After
+// rather than queued — under overload, shedding is cheaper than waiting.
+func LimitInFlight(max int) func(http.Handler) http.Handler {
+	slots := make(chan struct{}, max)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				w.Header().Set("Retry-After", "1")
+				WriteError(w, http.StatusServiceUnavailable, "overloaded",
+					fmt.Sprintf("more than %d requests in flight", max))
+				return
+			}
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // Chain applies middlewares right-to-left so the first listed runs outermost.
 //
 //	Chain(h, mw1, mw2, mw3) ≡ mw1(mw2(mw3(h)))
@@ -106,13 +188,13 @@ func demoMiddleware() {
 		),
 	)
 
-	// /public — only Logger
+	// /public — RequestID + Logger; the handler reads the ID back, typed
 	mux.Handle("GET /public",
 		Chain(
 			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				fmt.Fprintln(w, "public data")
+				fmt.Fprintf(w, "public data for request %s\n", RequestIDFrom(r.Context()))
 			}),
-			Logger,
+			RequestID, Logger,
 		),
 	)
 
@@ -138,8 +220,35 @@ func demoMiddleware() {
 	resp.Body.Close()
 	fmt.Printf("  GET /panic (recovered)         → %d\n\n", resp.StatusCode)
 
-	// Public → 200
-	resp, _ = http.Get(srv.URL + "/public")
+	// Public → 200, with the client's request ID echoed back
+	req, _ = http.NewRequest("GET", srv.URL+"/public", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	resp, _ = http.DefaultClient.Do(req)
+	body, _ = io.ReadAll(resp.Body)
 	resp.Body.Close()
-	fmt.Printf("  GET /public                    → %d\n", resp.StatusCode)
+	fmt.Printf("  GET /public (X-Request-ID)     → %d %q\n", resp.StatusCode, strings.TrimSpace(string(body)))
+
+	// DeadlineBudget — the handler sees only 80% of the caller's 1s budget
+	showDeadline := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, _ := r.Context().Deadline()
+		fmt.Printf("\n  DeadlineBudget(0.8): 1s request deadline → handler sees %s\n",
+			time.Until(d).Round(10*time.Millisecond))
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	budgetReq := httptest.NewRequest("GET", "/slow", nil).WithContext(ctx)
+	DeadlineBudget(0.8)(showDeadline).ServeHTTP(httptest.NewRecorder(), budgetReq)
+
+	// LimitInFlight — with one slot taken, a second concurrent request is shed
+	release := make(chan struct{})
+	busy := LimitInFlight(1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	go busy.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	time.Sleep(10 * time.Millisecond) // let it take the slot
+	rec := httptest.NewRecorder()
+	busy.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	close(release)
+	fmt.Printf("\n  LimitInFlight(1): second concurrent request → %d (Retry-After: %s)\n",
+		rec.Code, rec.Header().Get("Retry-After"))
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDeadlineBudgetShortensDeadline checks that a handler behind
+// DeadlineBudget(0.5) sees roughly half of the incoming request's remaining
+// time, and that the deadline is only ever moved earlier.
+func TestDeadlineBudgetShortensDeadline(t *testing.T) {
+	const total = 2 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), total)
+	defer cancel()
+	outer, _ := ctx.Deadline()
+
+	var inner time.Time
+	var hasDeadline bool
+	h := DeadlineBudget(0.5)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner, hasDeadline = r.Context().Deadline()
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+
+	if !hasDeadline {
+		t.Fatal("handler context has no deadline")
+	}
+	if !inner.Before(outer) {
+		t.Fatalf("inner deadline %v not before outer %v", inner, outer)
+	}
+	// Half of ~2s remaining → ~1s; allow slack for scheduling.
+	if got := time.Until(inner); got < 900*time.Millisecond || got > 1100*time.Millisecond {
+		t.Errorf("handler budget = %v; want about %v", got, total/2)
+	}
+}
+
+// TestDeadlineBudgetPassThrough checks that requests without a deadline, and
+// invalid fractions, reach the handler with the original context.
+func TestDeadlineBudgetPassThrough(t *testing.T) {
+	tests := []struct {
+		name        string
+		fraction    float64
+		withTimeout bool
+	}{
+		{"no deadline", 0.5, false},
+		{"fraction 0", 0, true},
+		{"fraction 1", 1, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.withTimeout {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, time.Second)
+				defer cancel()
+			}
+			req := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+
+			var seen context.Context
+			h := DeadlineBudget(tc.fraction)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = r.Context()
+			}))
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if seen != ctx {
+				t.Errorf("handler got a new context; want the request's own")
+			}
+		})
+	}
+}
+
+// TestLimitInFlight fills both slots of LimitInFlight(2) with blocking
+// handlers, checks that a third request gets 503 with Retry-After, then frees
+// one slot and checks that the next request is admitted.
+func TestLimitInFlight(t *testing.T) {
+	const max = 2
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	h := LimitInFlight(max)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/block" {
+			entered <- struct{}{}
+			<-release
+		}
+	}))
+
+	done := make(chan struct{})
+	for i := 0; i < max; i++ {
+		go func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/block", nil))
+			done <- struct{}{}
+		}()
+		<-entered // the slot is taken once the handler runs
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/fast", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("overflow request: got %d; want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("overflow response has no Retry-After header")
+	}
+
+	release <- struct{}{} // one blocking handler returns and frees its slot
+	<-done
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/fast", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("request after a slot freed: got %d; want %d", rec.Code, http.StatusOK)
+	}
+
+	close(release)
+	<-done
+}
+
+// TestLimitInFlightReleasesOnPanic checks that a panicking handler still
+// frees its slot, so the limit does not shrink permanently.
+func TestLimitInFlightReleasesOnPanic(t *testing.T) {
+	h := LimitInFlight(1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+	}))
+
+	func() {
+		defer func() { recover() }()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/panic", nil))
+	}()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/ok", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("after a panic: got %d; want %d (slot leaked)", rec.Code, http.StatusOK)
+	}
+}
+
+// TestRequestIDMiddleware checks that the middleware reuses an incoming
+// X-Request-ID, generates one otherwise, echoes it in the response, and that
+// RequestIDFrom returns it as a string inside the handler.
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFrom(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if seen != "req-42" || rec.Header().Get("X-Request-ID") != "req-42" {
+		t.Errorf("incoming ID: handler saw %q, response header %q; want \"req-42\" for both",
+			seen, rec.Header().Get("X-Request-ID"))
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if seen == "" || seen != rec.Header().Get("X-Request-ID") {
+		t.Errorf("generated ID: handler saw %q, response header %q; want the same non-empty ID",
+			seen, rec.Header().Get("X-Request-ID"))
+	}
+}
+
+// TestRequestIDFromWithoutMiddleware checks that the accessor returns "" for
+// a request that did not pass through RequestID, even if a string key with
+// the same name is present.
+func TestRequestIDFromWithoutMiddleware(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "RequestID", "spoofed") // the anti-pattern
+	if got := RequestIDFrom(ctx); got != "" {
+		t.Errorf("got %q; want \"\"", got)
+	}
+}
@@ -21,7 +21,7 @@ func userHandler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		id := r.PathValue("id")
 		if id == "" {
-			http.Error(w, "missing id", http.StatusBadRequest)
+			WriteError(w, http.StatusBadRequest, "missing_id", "path parameter id is required")
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -30,14 +30,14 @@ func userHandler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		var body map[string]any
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			WriteError(w, http.StatusBadRequest, "invalid_json", "request body is not valid JSON")
 			return
 		}
 		w.WriteHeader(http.StatusCreated)
 		json.NewEncoder(w).Encode(body)
 
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed")
 	}
 }
 
@@ -87,7 +87,11 @@ func demoRecorder() {
 
 	// Use a real http.Client to call the test server
 	client := &http.Client{}
-	resp, _ := client.Get(srv.URL + "/users/99")
+	resp, err := client.Get(srv.URL + "/users/99")
+	if err != nil {
+		fmt.Println("  GET /users/99 error:", err)
+		return
+	}
 	defer resp.Body.Close()
 	fmt.Printf("  GET /users/99 via http.Client  → %d\n", resp.StatusCode)
 
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// startReloadable reserves a free port, starts ListenAndServeReloadable on it
+// and waits until the server answers. The server stops when the test ends.
+func startReloadable(t *testing.T, current func() http.Handler) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserve port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ListenAndServeReloadable(ctx, addr, current) }()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("ListenAndServeReloadable() = %v; want nil", err)
+		}
+	})
+
+	for i := 0; i < 100; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return "http://" + addr
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("server never came up")
+	return ""
+}
+
+func getBody(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+// TestReloadSwapsHandler checks that requests after Reload hit the new
+// handler, while a request already in flight completes on the old one.
+func TestReloadSwapsHandler(t *testing.T) {
+	release := make(chan struct{})
+	inFlight := make(chan struct{})
+
+	v1 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(inFlight)
+			<-release
+		}
+		fmt.Fprint(w, "v1")
+	})
+	v2 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "v2")
+	})
+
+	current, reload := Reloadable(v1)
+	base := startReloadable(t, current)
+
+	if got := getBody(t, base+"/"); got != "v1" {
+		t.Fatalf("before reload: got %q; want v1", got)
+	}
+
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	<-inFlight
+
+	reload(v2)
+
+	if got := getBody(t, base+"/"); got != "v2" {
+		t.Errorf("after reload: got %q; want v2", got)
+	}
+
+	close(release)
+	if got := <-slow; got != "v1" {
+		t.Errorf("in-flight request: got %q; want v1 (must not be dropped)", got)
+	}
+}
+
+// TestListenAndServeReloadableBadAddr checks that a listen failure is
+// returned instead of blocking until ctx is cancelled.
+func TestListenAndServeReloadableBadAddr(t *testing.T) {
+	current, _ := Reloadable(http.NotFoundHandler())
+
+	err := ListenAndServeReloadable(context.Background(), "256.0.0.1:http", current)
+	if err == nil {
+		t.Fatal("expected an error for an invalid address")
+	}
+}
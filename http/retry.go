@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ── Retries with a shared budget ─────────────────────────────────────────────
+// Retrying each request a few times is fine while the backend is healthy. When
+// it is not, every client multiplies its load by (1 + MaxRetries) exactly when
+// the backend can least afford it — a retry storm.
+//
+// A RetryBudget is a token bucket shared by every request going through one
+// RetryTransport: each retry (never the first attempt) costs a token, and
+// tokens trickle back at a fixed rate. Once the bucket is empty, failures are
+// returned to the caller immediately, capping aggregate retry load.
+
+// RetryBudget is a token bucket of retry tokens. The zero value has no tokens;
+// use NewRetryBudget. It is safe for concurrent use.
+type RetryBudget struct {
+	mu          sync.Mutex
+	tokens      float64
+	max         float64
+	refillEvery time.Duration
+	last        time.Time
+	now         func() time.Time // overridable in tests
+}
+
+// NewRetryBudget returns a full budget of max tokens that regains one token
+// every refillEvery, never exceeding max.
+func NewRetryBudget(max int, refillEvery time.Duration) *RetryBudget {
+	return &RetryBudget{
+		tokens:      float64(max),
+		max:         float64(max),
+		refillEvery: refillEvery,
+		last:        time.Now(),
+		now:         time.Now,
+	}
+}
+
+// Allow consumes one token and reports whether a retry may proceed.
+func (b *RetryBudget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.refillEvery > 0 {
+		now := b.now()
+		b.tokens += float64(now.Sub(b.last)) / float64(b.refillEvery)
+		if b.tokens > b.max {
+			b.tokens = b.max
+		}
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RetryTransport is an http.RoundTripper that retries transport errors and 5xx
+// responses up to MaxRetries times, waiting Backoff between attempts.
+//
+// Only requests that can be replayed are retried: those without a body, or
+// whose GetBody is set (http.NewRequest sets it for bytes/strings readers).
+type RetryTransport struct {
+	Base       http.RoundTripper // nil → http.DefaultTransport
+	MaxRetries int
+	Backoff    time.Duration
+	Budget     *RetryBudget // nil → unlimited retries
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		// A RoundTripper must not modify req, so replays use a clone with a
+		// fresh body.
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+
+		resp, err := base.RoundTrip(r)
+		if !t.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body) // drain so the connection is reused
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(t.Backoff * time.Duration(attempt+1))
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// shouldRetry reports whether attempt failed retryably and a retry is allowed.
+// The budget is consulted last so that tokens are spent only on real retries.
+func (t *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if err == nil && resp.StatusCode < 500 {
+		return false
+	}
+	if attempt >= t.MaxRetries || req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // body already consumed and cannot be replayed
+	}
+	return t.Budget == nil || t.Budget.Allow()
+}
+
+func demoRetry() {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		WriteError(w, http.StatusServiceUnavailable, "unavailable", "backend overloaded")
+	}))
+	defer srv.Close()
+
+	run := func(label string, budget *RetryBudget) {
+		atomic.StoreInt64(&hits, 0)
+		client := &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond, Budget: budget},
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if resp, err := client.Get(srv.URL); err == nil {
+					resp.Body.Close()
+				}
+			}()
+		}
+		wg.Wait()
+
+		n := atomic.LoadInt64(&hits)
+		fmt.Printf("  %-28s 20 requests → %2d backend hits (%d retries)\n", label, n, n-20)
+	}
+
+	run("no budget (MaxRetries=3):", nil)
+	run("budget of 5 retry tokens:", NewRetryBudget(5, time.Minute))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRetryBudgetBoundsRetries fires many concurrent requests at an always
+// failing backend and checks that the total number of retries never exceeds
+// the budget, even though each request alone would retry MaxRetries times.
+func TestRetryBudgetBoundsRetries(t *testing.T) {
+	const (
+		requests   = 50
+		maxRetries = 3
+		budget     = 10
+	)
+
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// refillEvery of an hour: no tokens come back within the test window.
+	client := &http.Client{Transport: &RetryTransport{
+		MaxRetries: maxRetries,
+		Backoff:    time.Millisecond,
+		Budget:     NewRetryBudget(budget, time.Hour),
+	}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Errorf("GET: %v", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("status = %d; want 503", resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	retries := atomic.LoadInt64(&hits) - requests
+	if retries != budget {
+		t.Errorf("retries = %d; want exactly the budget (%d), not %d", retries, budget, requests*maxRetries)
+	}
+}
+
+// TestRetryBudgetRefill checks the token bucket arithmetic with a fake clock:
+// an exhausted budget regains one token per refillEvery, capped at max.
+func TestRetryBudgetRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewRetryBudget(2, time.Second)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	steps := []struct {
+		advance time.Duration
+		want    bool
+	}{
+		{0, true},
+		{0, true},
+		{0, false},                      // empty
+		{500 * time.Millisecond, false}, // half a token
+		{500 * time.Millisecond, true},  // one full token
+		{10 * time.Second, true},        // refilled, capped at 2
+		{0, true},
+		{0, false},
+	}
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		if got := b.Allow(); got != s.want {
+			t.Errorf("step %d (+%s): Allow() = %v; want %v", i, s.advance, got, s.want)
+		}
+	}
+}
+
+// TestRetryTransportRecovers checks that without a budget a transient failure
+// is retried until the backend recovers.
+func TestRetryTransportRecovers(t *testing.T) {
+	var hits int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&hits, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Backoff: time.Millisecond}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || hits != 3 {
+		t.Errorf("got status %d after %d hits; want 200 after 3", resp.StatusCode, hits)
+	}
+}
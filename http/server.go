@@ -57,7 +57,7 @@ func newRouter() *http.ServeMux {
 	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
 		var body map[string]any
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			WriteError(w, http.StatusBadRequest, "invalid_json", "request body is not valid JSON")
 			return
 		}
 		defer r.Body.Close()
@@ -70,7 +70,7 @@ func newRouter() *http.ServeMux {
 	mux.HandleFunc("POST /echo", func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "read error", http.StatusBadRequest)
+			WriteError(w, http.StatusBadRequest, "read_error", "could not read request body")
 			return
 		}
 		defer r.Body.Close()
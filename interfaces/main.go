@@ -116,6 +116,18 @@ func main() {
 	fmt.Println("\n=== Aggregation over interface slice ===")
 	fmt.Printf("  Total area of all shapes: %.4f\n", totalArea(shapes))
 
+	// --- Registry: construct by name ---
+	fmt.Println("\n=== Registry: construct shapes by name ===")
+	for _, name := range []string{"rectangle", "hexagon"} {
+		shape, err := NewShape(name, map[string]float64{"width": 2, "height": 3})
+		if err != nil {
+			fmt.Printf("  NewShape(%q): %v\n", name, err)
+			continue
+		}
+		fmt.Printf("  NewShape(%q) → %T, area %.2f\n", name, shape, shape.Area())
+	}
+	fmt.Printf("  registered: %v\n", ShapeNames())
+
 	// --- nil interface ---
 	fmt.Println("\n=== nil interface ===")
 	var nilShape Shape // zero value of an interface is nil
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ShapeFactory builds a Shape from named numeric parameters
+// (e.g. {"radius": 2} for a circle).
+type ShapeFactory func(params map[string]float64) (Shape, error)
+
+// ErrUnknownShape is returned by NewShape for a name nobody registered.
+var ErrUnknownShape = errors.New("unknown shape")
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ShapeFactory)
+)
+
+// RegisterShape makes a shape constructible by name through NewShape. Like
+// database/sql.Register it is meant to be called from init and panics if
+// the name is empty, already taken, or the factory is nil — those are
+// programming errors, not runtime conditions.
+func RegisterShape(name string, factory func(params map[string]float64) (Shape, error)) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" || factory == nil {
+		panic("interfaces: RegisterShape needs a name and a factory")
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("interfaces: shape %q registered twice", name))
+	}
+	registry[name] = factory
+}
+
+// NewShape constructs the shape registered under name. The concrete type is
+// chosen at runtime — the caller only ever sees the Shape interface.
+func NewShape(name string, params map[string]float64) (Shape, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownShape, name)
+	}
+	s, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("shape %q: %w", name, err)
+	}
+	return s, nil
+}
+
+// ShapeNames returns the registered names, sorted.
+func ShapeNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// param returns params[key], or an error naming the missing key.
+func param(params map[string]float64, key string) (float64, error) {
+	v, ok := params[key]
+	if !ok {
+		return 0, fmt.Errorf("missing parameter %q", key)
+	}
+	return v, nil
+}
+
+func init() {
+	RegisterShape("circle", func(p map[string]float64) (Shape, error) {
+		r, err := param(p, "radius")
+		return Circle{Radius: r}, err
+	})
+	RegisterShape("rectangle", func(p map[string]float64) (Shape, error) {
+		w, err := param(p, "width")
+		if err != nil {
+			return nil, err
+		}
+		h, err := param(p, "height")
+		return Rectangle{Width: w, Height: h}, err
+	})
+	RegisterShape("triangle", func(p map[string]float64) (Shape, error) {
+		var sides [3]float64
+		for i, key := range []string{"a", "b", "c"} {
+			v, err := param(p, key)
+			if err != nil {
+				return nil, err
+			}
+			sides[i] = v
+		}
+		return Triangle{A: sides[0], B: sides[1], C: sides[2]}, nil
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Square is a user-defined shape, registered the way a third-party package
+// would add its own.
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64      { return s.Side * s.Side }
+func (s Square) Perimeter() float64 { return 4 * s.Side }
+
+func init() {
+	RegisterShape("square", func(p map[string]float64) (Shape, error) {
+		side, err := param(p, "side")
+		return Square{Side: side}, err
+	})
+}
+
+// TestNewShapeCustomSquare constructs the registered Square by name.
+func TestNewShapeCustomSquare(t *testing.T) {
+	s, err := NewShape("square", map[string]float64{"side": 3})
+	if err != nil {
+		t.Fatalf("NewShape(square): %v", err)
+	}
+	sq, ok := s.(Square)
+	if !ok {
+		t.Fatalf("got %T; want Square", s)
+	}
+	if sq.Area() != 9 || sq.Perimeter() != 12 {
+		t.Errorf("got area %v perimeter %v; want 9 and 12", sq.Area(), sq.Perimeter())
+	}
+}
+
+// TestNewShapeBuiltins checks that the built-in shapes are registered.
+func TestNewShapeBuiltins(t *testing.T) {
+	s, err := NewShape("circle", map[string]float64{"radius": 1})
+	if err != nil {
+		t.Fatalf("NewShape(circle): %v", err)
+	}
+	if got := s.Area(); math.Abs(got-math.Pi) > 1e-9 {
+		t.Errorf("circle area = %v; want %v", got, math.Pi)
+	}
+}
+
+// TestNewShapeErrors checks the unknown-name and missing-parameter errors.
+func TestNewShapeErrors(t *testing.T) {
+	if _, err := NewShape("hexagon", nil); !errors.Is(err, ErrUnknownShape) {
+		t.Errorf("NewShape(hexagon) = %v; want ErrUnknownShape", err)
+	}
+	if _, err := NewShape("rectangle", map[string]float64{"width": 2}); err == nil {
+		t.Error("NewShape(rectangle) without height = nil error; want one")
+	}
+}
+
+// TestRegisterShapeDuplicatePanics checks that a name cannot be registered twice.
+func TestRegisterShapeDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering \"circle\" again did not panic")
+		}
+	}()
+	RegisterShape("circle", func(map[string]float64) (Shape, error) { return Circle{}, nil })
+}
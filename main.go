@@ -12,6 +12,12 @@ import (
 	_ "net/http/pprof"
 )
 
+// rng drives the simulated latencies. It is a package-level *rand.Rand rather
+// than the global math/rand functions so tests can swap in a fixed seed and get
+// a reproducible sequence. *rand.Rand is not safe for concurrent use, so it is
+// only touched from main's goroutine.
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
 type result struct {
 	Service string
 	Value   string
@@ -20,7 +26,6 @@ type result struct {
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
 	go func() {
 		http.ListenAndServe("localhost:6060", nil)
 	}()
@@ -36,8 +41,8 @@ func main() {
 	resultsCh := make(chan result)
 
 	// Lanzamos 2 "llamadas" concurrentes
-	go callService(ctx, "payments", 3*time.Millisecond, 6*time.Millisecond, resultsCh)
-	go callService(ctx, "shipping", 3*time.Millisecond, 6*time.Millisecond, resultsCh)
+	go callService(ctx, "payments", randomDelay(rng, 3*time.Millisecond, 6*time.Millisecond), resultsCh)
+	go callService(ctx, "shipping", randomDelay(rng, 3*time.Millisecond, 6*time.Millisecond), resultsCh)
 
 	// Recolectamos 2 resultados o cancelamos
 	want := 2
@@ -66,9 +71,13 @@ func main() {
 	printSummary(results, want)
 }
 
-func callService(ctx context.Context, name string, minDelay, maxDelay time.Duration, out chan<- result) {
-	// Simulamos latencia variable
-	delay := minDelay + time.Duration(rand.Int63n(int64(maxDelay-minDelay)))
+// randomDelay returns a latency in [minDelay, maxDelay) drawn from r.
+func randomDelay(r *rand.Rand, minDelay, maxDelay time.Duration) time.Duration {
+	return minDelay + time.Duration(r.Int63n(int64(maxDelay-minDelay)))
+}
+
+func callService(ctx context.Context, name string, delay time.Duration, out chan<- result) {
+	// Latencia simulada: el caller la elige con randomDelay
 	time.Sleep(5 * time.Second)
 	start := time.Now()
 	select {
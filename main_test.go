@@ -0,0 +1,27 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestRandomDelayReproducible checks that the same seed yields the same
+// latency sequence and that every value stays within [min, max).
+func TestRandomDelayReproducible(t *testing.T) {
+	const minDelay, maxDelay = 3 * time.Millisecond, 6 * time.Millisecond
+
+	a := rand.New(rand.NewSource(42))
+	b := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 100; i++ {
+		da := randomDelay(a, minDelay, maxDelay)
+		db := randomDelay(b, minDelay, maxDelay)
+		if da != db {
+			t.Fatalf("draw %d: %s != %s with the same seed", i, da, db)
+		}
+		if da < minDelay || da >= maxDelay {
+			t.Fatalf("draw %d: %s outside [%s, %s)", i, da, minDelay, maxDelay)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestActorConcurrentSendAsk mixes concurrent Sends and Asks and checks that
+// no mutation is lost and every Ask computes from a consistent state.
+func TestActorConcurrentSendAsk(t *testing.T) {
+	type state struct {
+		items []int
+		sum   int
+	}
+	a := NewActor(state{})
+	defer a.Close()
+
+	const goroutines, perGoroutine = 20, 100
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				v := g*perGoroutine + i
+				a.Send(func(s *state) {
+					s.items = append(s.items, v)
+					s.sum += v
+				})
+				if i%10 == 0 {
+					// The invariant sum == Σ items must hold whenever we look.
+					ok := Ask(a, func(s *state) bool {
+						total := 0
+						for _, x := range s.items {
+							total += x
+						}
+						return total == s.sum
+					})
+					if !ok {
+						t.Error("Ask saw sum != Σ items: inconsistent state")
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	n := goroutines * perGoroutine
+	gotLen, gotSum := Ask(a, func(s *state) int { return len(s.items) }), Ask(a, func(s *state) int { return s.sum })
+	if wantSum := n * (n - 1) / 2; gotLen != n || gotSum != wantSum {
+		t.Errorf("got %d items summing to %d; want %d summing to %d", gotLen, gotSum, n, wantSum)
+	}
+}
+
+// TestActorAskReturnsValue checks that Ask returns fn's result computed after
+// earlier Sends from the same goroutine.
+func TestActorAskReturnsValue(t *testing.T) {
+	a := NewActor(map[string]int{})
+	defer a.Close()
+
+	a.Send(func(m *map[string]int) { (*m)["x"] = 2 })
+	a.Send(func(m *map[string]int) { (*m)["y"] = 3 })
+
+	if got := Ask(a, func(m *map[string]int) int { return (*m)["x"] * (*m)["y"] }); got != 6 {
+		t.Errorf("got %d; want 6", got)
+	}
+}
+
+// TestActorCloseDrainsInbox checks that Close runs already-queued messages
+// before the goroutine exits.
+func TestActorCloseDrainsInbox(t *testing.T) {
+	var ran int
+	a := NewActor(0)
+	for i := 0; i < 10; i++ {
+		a.Send(func(n *int) { *n++; ran = *n })
+	}
+	a.Close()
+
+	if ran != 10 {
+		t.Errorf("ran %d messages before Close returned; want 10", ran)
+	}
+}
@@ -46,82 +46,112 @@ func demoCounterRace() {
 		expected, counter, expected-counter)
 }
 
-// demoCounterMutex fixes the race by wrapping the critical section with a Mutex.
-// Only one goroutine can be inside Lock/Unlock at a time.
-func demoCounterMutex() {
-	var (
-		counter int
-		mu      sync.Mutex
-		wg      sync.WaitGroup
-	)
+// Counter is the shape shared by the three race-free counters below, so the
+// same harness (RunConcurrent) can drive and compare them.
+type Counter interface {
+	Inc()
+	Value() int64
+}
 
+// RunConcurrent has `goroutines` goroutines call c.Inc perGoroutine times
+// each, waits for all of them, and returns c.Value(). A correct Counter
+// returns goroutines*perGoroutine.
+func RunConcurrent(c Counter, goroutines, perGoroutine int) int64 {
+	var wg sync.WaitGroup
 	for i := 0; i < goroutines; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for j := 0; j < increments; j++ {
-				mu.Lock()
-				counter++ // protected: only one goroutine here at a time
-				mu.Unlock()
+			for j := 0; j < perGoroutine; j++ {
+				c.Inc()
 			}
 		}()
 	}
-
 	wg.Wait()
-	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, counter)
+	return c.Value()
 }
 
-// demoCounterAtomic fixes the race with a single CPU instruction.
-// Cheaper than a Mutex for simple numeric operations.
-func demoCounterAtomic() {
-	var counter atomic.Int64
-	var wg sync.WaitGroup
+// MutexCounter guards a plain int64 with a Mutex: only one goroutine can be
+// inside Lock/Unlock at a time.
+type MutexCounter struct {
+	mu sync.Mutex
+	n  int64
+}
 
-	for i := 0; i < goroutines; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for j := 0; j < increments; j++ {
-				counter.Add(1) // atomic: single indivisible instruction
-			}
-		}()
-	}
+func (c *MutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++ // protected: only one goroutine here at a time
+	c.mu.Unlock()
+}
 
-	wg.Wait()
-	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, counter.Load())
+func (c *MutexCounter) Value() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// AtomicCounter increments with a single indivisible CPU instruction.
+// Cheaper than a Mutex for simple numeric operations.
+type AtomicCounter struct {
+	n atomic.Int64
 }
 
-// demoCounterChannel fixes the race with the actor model: a single goroutine
-// owns the counter and is the only one that reads or writes it.
-// All other goroutines send increment requests via a channel.
+func (c *AtomicCounter) Inc()         { c.n.Add(1) }
+func (c *AtomicCounter) Value() int64 { return c.n.Load() }
+
+// ChannelCounter follows the actor model: a single goroutine owns the count
+// and is the only one that reads or writes it. Inc and Value are messages on
+// the same channel, so a Value sees every Inc sent before it.
 //
-// No shared memory → no race by construction.
-func demoCounterChannel() {
-	inc := make(chan struct{}, 512) // buffer absorbs bursts
-	done := make(chan int)
+// No shared memory → no race by construction. Call Close to stop the actor.
+type ChannelCounter struct {
+	ops chan chan int64 // nil message = increment; non-nil = reply with the value
+}
 
-	// Actor: sole owner of the counter.
+// NewChannelCounter starts the actor goroutine.
+func NewChannelCounter() *ChannelCounter {
+	c := &ChannelCounter{ops: make(chan chan int64, 512)} // buffer absorbs bursts
 	go func() {
-		counter := 0
-		for range inc {
-			counter++
+		var n int64 // sole owner of the counter
+		for reply := range c.ops {
+			if reply == nil {
+				n++
+			} else {
+				reply <- n
+			}
 		}
-		done <- counter
 	}()
+	return c
+}
 
-	var wg sync.WaitGroup
-	for i := 0; i < goroutines; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for j := 0; j < increments; j++ {
-				inc <- struct{}{}
-			}
-		}()
-	}
+func (c *ChannelCounter) Inc() { c.ops <- nil }
 
-	wg.Wait()
-	close(inc)   // signal actor: no more increments
-	counter := <-done
-	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, counter)
+func (c *ChannelCounter) Value() int64 {
+	reply := make(chan int64)
+	c.ops <- reply
+	return <-reply
+}
+
+// Close stops the actor goroutine. The counter must not be used afterwards.
+func (c *ChannelCounter) Close() { close(c.ops) }
+
+// demoCounterMutex fixes the race by wrapping the critical section with a Mutex.
+func demoCounterMutex() {
+	got := RunConcurrent(&MutexCounter{}, goroutines, increments)
+	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, got)
+}
+
+// demoCounterAtomic fixes the race with sync/atomic.
+func demoCounterAtomic() {
+	got := RunConcurrent(&AtomicCounter{}, goroutines, increments)
+	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, got)
+}
+
+// demoCounterChannel fixes the race by funnelling every access through one
+// goroutine.
+func demoCounterChannel() {
+	c := NewChannelCounter()
+	defer c.Close()
+	got := RunConcurrent(c, goroutines, increments)
+	fmt.Printf("  expected: %d  got: %d  ✓\n", expected, got)
 }
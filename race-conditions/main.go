@@ -15,6 +15,9 @@ func main() {
 	section("Counter fix — channel (actor)")
 	demoCounterChannel()
 
+	section("Actor[S] — Send / Ask over a single owner goroutine")
+	demoActor()
+
 	section("Map race — fatal concurrent access")
 	demoMapRace()
 
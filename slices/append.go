@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"unsafe"
+)
 
 // append has two distinct behaviors depending on capacity:
 //
@@ -61,6 +64,14 @@ func demoAppend() {
 	printS("orig2", orig2) // untouched
 	printS("safe", safe)   // new backing array
 
+	// SafeAppend packages the fix: it appends through s[:len:len].
+	orig3 := []int{1, 2, 3, 4, 5}
+	sub3 := SafeAppend(orig3[1:3], 99)
+	fmt.Println("\n  SafeAppend(orig3[1:3], 99) — same fix as a helper:")
+	printS("orig3", orig3) // untouched
+	printS("sub3", sub3)
+	fmt.Println("  MayAlias(orig, sub):", MayAlias(orig, sub), " MayAlias(orig3, sub3):", MayAlias(orig3, sub3))
+
 	// ── Pre-allocate for known sizes ──────────────────────────────────────────
 	// Without pre-allocation, each capacity overflow copies all elements.
 	// With make([]T, 0, n), a single allocation handles everything.
@@ -71,4 +82,75 @@ func demoAppend() {
 	}
 	fmt.Println("  result:", result)
 	fmt.Printf("  len=%d cap=%d (no reallocation occurred)\n", len(result), cap(result))
+
+	// ── Exact growth and independent copies ──────────────────────────────────
+	fmt.Println("\n  GrowExact — room for exactly n more, vs append's headroom:")
+	base := []int{1, 2, 3}
+	fmt.Printf("  append(base, 4):      cap=%d\n", cap(append(base, 4)))
+	fmt.Printf("  GrowExact(base, 1):   cap=%d\n", cap(GrowExact(base, 1)))
+
+	fmt.Println("\n  Clone — independent backing array:")
+	c := Clone(base)
+	c[0] = 99
+	printS("base", base) // untouched
+	printS("c[0]=99", c)
+	fmt.Println("  Clone(nil) == nil:", Clone[int](nil) == nil)
+}
+
+// GrowExact returns s with capacity for exactly n more elements: if s lacks
+// room, the new backing array has cap len(s)+n, where append would round up
+// and leave headroom. Use it when the final size is known and memory
+// matters; otherwise prefer slices.Grow. s is returned unchanged if it
+// already has room. It panics if n is negative.
+//
+// Like append, the result may share s's backing array, so use its return
+// value: s = GrowExact(s, n).
+func GrowExact[T any](s []T, n int) []T {
+	if n < 0 {
+		panic("GrowExact: negative n")
+	}
+	if cap(s)-len(s) >= n {
+		return s
+	}
+	out := make([]T, len(s), len(s)+n)
+	copy(out, s)
+	return out
+}
+
+// Clone returns a copy of s with its own backing array, so writes to either
+// never show in the other. A nil slice clones to nil and an empty one to an
+// empty non-nil slice (the distinction nil.go is about).
+func Clone[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// SafeAppend appends vs to s like append, but never writes into s's backing
+// array past len(s): the capacity beyond it may belong to a parent slice
+// (the subslice gotcha above). It appends to s[:len(s):len(s)], whose cap
+// equals its len, so any element added forces a fresh array.
+//
+// The cost is an allocation and copy on every call that adds elements, so
+// use it where s may be a view into someone else's slice, not in hot loops
+// that own their slice.
+func SafeAppend[T any](s []T, vs ...T) []T {
+	return append(s[:len(s):len(s)], vs...)
+}
+
+// MayAlias is the detector for the same gotcha: it reports whether the
+// backing arrays of a and b overlap anywhere up to their capacities, in which
+// case an append to one can overwrite elements of the other. It compares
+// addresses with unsafe, the only way to see where a slice points.
+func MayAlias[T any](a, b []T) bool {
+	if cap(a) == 0 || cap(b) == 0 {
+		return false
+	}
+	size := unsafe.Sizeof(*new(T))
+	aStart := uintptr(unsafe.Pointer(unsafe.SliceData(a)))
+	bStart := uintptr(unsafe.Pointer(unsafe.SliceData(b)))
+	aEnd := aStart + uintptr(cap(a))*size
+	bEnd := bStart + uintptr(cap(b))*size
+	return aStart < bEnd && bStart < aEnd
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestGrowExactCapacity checks that growing a full slice gives cap len+n
+// exactly, keeps the elements, and that a slice with room is returned as is.
+func TestGrowExactCapacity(t *testing.T) {
+	for _, n := range []int{0, 1, 5, 100} {
+		s := []int{1, 2, 3} // len == cap
+		g := GrowExact(s, n)
+		if got, want := cap(g), len(s)+n; got != want {
+			t.Errorf("cap(GrowExact(s, %d)) = %d; want %d", n, got, want)
+		}
+		if !slices.Equal(g, s) {
+			t.Errorf("GrowExact(s, %d) = %v; want elements %v", n, g, s)
+		}
+	}
+
+	roomy := make([]int, 2, 10)
+	if g := GrowExact(roomy, 3); cap(g) != 10 || &g[0] != &roomy[0] {
+		t.Errorf("GrowExact with room: cap=%d, same array=%v; want 10, true", cap(g), &g[0] == &roomy[0])
+	}
+}
+
+// TestCloneIndependent checks that writes to a clone don't reach the
+// original, even when the original has spare capacity, and that nil and
+// empty slices keep their nil-ness.
+func TestCloneIndependent(t *testing.T) {
+	orig := make([]int, 3, 8)
+	copy(orig, []int{1, 2, 3})
+
+	c := Clone(orig)
+	c[0] = 99
+	c = append(c, 4)
+	if want := []int{1, 2, 3}; !slices.Equal(orig, want) {
+		t.Errorf("orig = %v after writing to the clone; want %v", orig, want)
+	}
+	if full := orig[:4]; full[3] != 0 {
+		t.Errorf("append to the clone wrote %d into orig's spare capacity", full[3])
+	}
+
+	if Clone[int](nil) != nil {
+		t.Error("Clone(nil) != nil; want nil")
+	}
+	if e := Clone([]int{}); e == nil || len(e) != 0 {
+		t.Errorf("Clone([]int{}) = %#v; want a non-nil empty slice", e)
+	}
+}
+
+// TestSafeAppendLeavesParentIntact appends to a subslice that has spare
+// capacity in its parent, first with plain append (which overwrites the
+// parent, the gotcha) and then with SafeAppend (which does not).
+func TestSafeAppendLeavesParentIntact(t *testing.T) {
+	parent := []int{1, 2, 3, 4, 5}
+	_ = append(parent[1:3], 99)
+	if parent[3] != 99 {
+		t.Fatalf("plain append: parent = %v; want parent[3] overwritten with 99", parent)
+	}
+
+	parent = []int{1, 2, 3, 4, 5}
+	got := SafeAppend(parent[1:3], 99, 100)
+	if want := []int{1, 2, 3, 4, 5}; !slices.Equal(parent, want) {
+		t.Errorf("SafeAppend: parent = %v; want %v", parent, want)
+	}
+	if want := []int{2, 3, 99, 100}; !slices.Equal(got, want) {
+		t.Errorf("SafeAppend = %v; want %v", got, want)
+	}
+
+	got[0] = -1 // the result has its own array, so this can't reach parent either
+	if parent[1] != 2 {
+		t.Errorf("writing to the result changed parent[1] to %d", parent[1])
+	}
+}
+
+// TestMayAlias checks the detector on a subslice, its SafeAppend result,
+// disjoint halves of a 3-index split, and unrelated slices.
+func TestMayAlias(t *testing.T) {
+	parent := []int{1, 2, 3, 4, 5}
+	cases := []struct {
+		name string
+		a, b []int
+		want bool
+	}{
+		{"subslice", parent, parent[1:3], true},
+		{"SafeAppend result", parent, SafeAppend(parent[1:3], 9), false},
+		{"3-index split", parent[:2:2], parent[2:], false},
+		{"unrelated", parent, []int{1, 2, 3}, false},
+		{"nil", parent, nil, false},
+	}
+	for _, tc := range cases {
+		if got := MayAlias(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: MayAlias = %v; want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -57,6 +57,11 @@ func demoNil() {
 	fmt.Println("  DeepEqual(nil, []int{})  =", reflect.DeepEqual([]int(nil), []int{}))
 	fmt.Println("  DeepEqual([]int{}, []int{}) =", reflect.DeepEqual([]int{}, []int{}))
 
+	// Make the choice explicit instead of relying on DeepEqual's behaviour.
+	fmt.Println("\n  Explicit helpers — pick the semantics you mean:")
+	fmt.Println("  SlicesEqualLenient(nil, []int{}) =", SlicesEqualLenient([]int(nil), []int{}))
+	fmt.Println("  SlicesEqualStrict(nil, []int{})  =", SlicesEqualStrict([]int(nil), []int{}))
+
 	// ── Slices are NOT comparable with == ────────────────────────────────────
 	// Two non-nil slices cannot be compared directly — it's a compile error.
 	// The only valid == comparison for a slice is against nil.
@@ -80,3 +85,28 @@ func demoNil() {
 	fmt.Println("  append to nil slice → safe (returns new slice)")
 	fmt.Println("  write to nil map    → panic: assignment to entry in nil map")
 }
+
+// SlicesEqualLenient reports whether a and b hold the same elements in the
+// same order, treating nil and empty as equal. This is what most callers
+// mean by "equal" (and what slices.Equal does).
+func SlicesEqualLenient[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SlicesEqualStrict is like SlicesEqualLenient but also requires a and b to
+// agree on nil-ness, matching reflect.DeepEqual: nil ≠ []T{}. Use it when
+// the distinction is observable, e.g. JSON null vs [].
+func SlicesEqualStrict[T comparable](a, b []T) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return SlicesEqualLenient(a, b)
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+// TestSlicesEqual covers the nil-vs-empty distinction and content mismatches
+// for both the lenient and strict comparisons.
+func TestSlicesEqual(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        []int
+		wantLenient bool
+		wantStrict  bool
+	}{
+		{"nil vs nil", nil, nil, true, true},
+		{"nil vs empty", nil, []int{}, true, false},
+		{"empty vs nil", []int{}, nil, true, false},
+		{"empty vs empty", []int{}, make([]int, 0), true, true},
+		{"same contents", []int{1, 2, 3}, []int{1, 2, 3}, true, true},
+		{"different element", []int{1, 2, 3}, []int{1, 9, 3}, false, false},
+		{"different length", []int{1, 2}, []int{1, 2, 3}, false, false},
+		{"nil vs non-empty", nil, []int{1}, false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SlicesEqualLenient(tc.a, tc.b); got != tc.wantLenient {
+				t.Errorf("SlicesEqualLenient(%v, %v) = %v; want %v", tc.a, tc.b, got, tc.wantLenient)
+			}
+			if got := SlicesEqualStrict(tc.a, tc.b); got != tc.wantStrict {
+				t.Errorf("SlicesEqualStrict(%v, %v) = %v; want %v", tc.a, tc.b, got, tc.wantStrict)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBarrierBroken is returned by Await when a waiter of the current phase
+// gave up (its ctx was cancelled) or Reset was called, so the phase can
+// never complete.
+var ErrBarrierBroken = errors.New("sync: barrier is broken")
+
+// CyclicBarrier lets a fixed number of goroutines meet at a point, phase after
+// phase. Each Await blocks until all parties have arrived; the last one to
+// arrive runs onTrip (if any) before anybody is released, and the barrier
+// resets itself for the next phase.
+//
+// If a waiter's context is cancelled, the barrier breaks: every goroutine
+// waiting in that phase, and every later Await, gets ErrBarrierBroken until
+// Reset is called. Otherwise the remaining parties would wait forever for
+// one that is never coming.
+type CyclicBarrier struct {
+	mu      sync.Mutex
+	parties int
+	onTrip  func()
+	arrived int
+	gen     *generation
+}
+
+// generation is one phase of the barrier. done is closed when the phase trips
+// or breaks; broken tells the two apart.
+type generation struct {
+	done   chan struct{}
+	broken bool
+}
+
+// NewBarrier returns a barrier for parties goroutines. onTrip may be nil.
+func NewBarrier(parties int, onTrip func()) *CyclicBarrier {
+	if parties <= 0 {
+		panic("sync: NewBarrier needs at least one party")
+	}
+	return &CyclicBarrier{
+		parties: parties,
+		onTrip:  onTrip,
+		gen:     &generation{done: make(chan struct{})},
+	}
+}
+
+// Await waits until all parties have called Await for the current phase.
+// It returns nil when the phase trips, ErrBarrierBroken if the phase breaks,
+// and an error matching both ErrBarrierBroken and ctx.Err() if this caller's
+// ctx is what broke it.
+func (b *CyclicBarrier) Await(ctx context.Context) error {
+	b.mu.Lock()
+	g := b.gen
+	if g.broken {
+		b.mu.Unlock()
+		return ErrBarrierBroken
+	}
+
+	b.arrived++
+	if b.arrived == b.parties {
+		// Last to arrive: run the trip action while everyone is still
+		// parked, then release them and start the next phase.
+		if b.onTrip != nil {
+			b.onTrip()
+		}
+		close(g.done)
+		b.gen = &generation{done: make(chan struct{})}
+		b.arrived = 0
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-g.done:
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		select {
+		case <-g.done:
+			// The phase completed (or broke) while we were giving up; report
+			// what actually happened to it.
+		default:
+			b.breakLocked()
+			return fmt.Errorf("%w: %w", ErrBarrierBroken, ctx.Err())
+		}
+	}
+
+	if g.broken {
+		return ErrBarrierBroken
+	}
+	return nil
+}
+
+// Reset breaks the current phase, if anyone is waiting in it, and starts a
+// fresh one so the barrier can be reused.
+func (b *CyclicBarrier) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.gen.broken {
+		b.breakLocked()
+	}
+	b.gen = &generation{done: make(chan struct{})}
+	b.arrived = 0
+}
+
+// breakLocked marks the current generation broken and wakes its waiters.
+// b.mu must be held.
+func (b *CyclicBarrier) breakLocked() {
+	b.gen.broken = true
+	close(b.gen.done)
+}
+
+// demoBarrier runs three workers through two phases; nobody starts phase 2
+// until everyone has finished phase 1.
+func demoBarrier() {
+	const workers = 3
+	phase := 1
+	barrier := NewBarrier(workers, func() {
+		fmt.Printf("  ── phase %d complete ──\n", phase)
+		phase++
+	})
+
+	var wg sync.WaitGroup
+	for id := 1; id <= workers; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for p := 1; p <= 2; p++ {
+				time.Sleep(time.Duration(id*10) * time.Millisecond)
+				fmt.Printf("  worker %d finished phase %d\n", id, p)
+				if err := barrier.Await(context.Background()); err != nil {
+					fmt.Printf("  worker %d: %v\n", id, err)
+					return
+				}
+			}
+		}(id)
+	}
+	wg.Wait()
+}
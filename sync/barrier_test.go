@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBarrierTwoPhases runs 3 goroutines through two phases and checks that
+// no goroutine enters phase 2 before all have finished phase 1, and that
+// onTrip runs once per phase.
+func TestBarrierTwoPhases(t *testing.T) {
+	const parties = 3
+
+	var mu sync.Mutex
+	var log []int // phase numbers in the order goroutines finish them
+	trips := 0
+
+	barrier := NewBarrier(parties, func() { trips++ }) // runs under the barrier's lock
+
+	var wg sync.WaitGroup
+	for i := 0; i < parties; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for phase := 1; phase <= 2; phase++ {
+				time.Sleep(time.Duration(id) * 5 * time.Millisecond)
+				mu.Lock()
+				log = append(log, phase)
+				mu.Unlock()
+				if err := barrier.Await(context.Background()); err != nil {
+					t.Errorf("Await: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	want := []int{1, 1, 1, 2, 2, 2}
+	if len(log) != len(want) {
+		t.Fatalf("log = %v; want %v", log, want)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("log = %v; want %v (a phase-2 step ran before phase 1 finished)", log, want)
+		}
+	}
+	if trips != 2 {
+		t.Errorf("onTrip ran %d times; want 2", trips)
+	}
+}
+
+// TestBarrierCancelBreaks cancels one of three waiters and checks that the
+// others are released with ErrBarrierBroken, the cancelled one gets an error
+// matching both ErrBarrierBroken and context.Canceled, and later Awaits fail
+// until Reset.
+func TestBarrierCancelBreaks(t *testing.T) {
+	barrier := NewBarrier(3, nil)
+
+	errc := make(chan error, 1)
+	go func() { errc <- barrier.Await(context.Background()) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() { cancelledErr <- barrier.Await(ctx) }()
+
+	time.Sleep(20 * time.Millisecond) // let both goroutines park
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrBarrierBroken) {
+			t.Errorf("other waiter got %v; want ErrBarrierBroken", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("other waiter still blocked after cancellation")
+	}
+
+	err := <-cancelledErr
+	if !errors.Is(err, ErrBarrierBroken) || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled waiter got %v; want ErrBarrierBroken and context.Canceled", err)
+	}
+
+	if err := barrier.Await(context.Background()); !errors.Is(err, ErrBarrierBroken) {
+		t.Errorf("Await on broken barrier = %v; want ErrBarrierBroken", err)
+	}
+
+	barrier.Reset()
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	if err := barrier.Await(ctx2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Await after Reset = %v; want it to wait (and time out)", err)
+	}
+}
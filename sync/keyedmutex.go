@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// KeyedMutex gives every key its own mutex, so critical sections for
+// different entities (user 1 vs user 2) run in parallel while those for the
+// same entity are serialised — without one global lock.
+//
+// Per-key mutexes are created on first use and reference-counted: when the
+// last holder or waiter unlocks, the entry is removed, so the map only holds
+// keys that are currently in use.
+//
+// The zero value is ready to use.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex // guards locks and every refcount
+	locks map[K]*refMutex
+}
+
+// refMutex is a per-key mutex plus the number of goroutines holding or
+// waiting for it.
+type refMutex struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock acquires the mutex for k and returns the function that releases it.
+// The returned unlock must be called exactly once.
+//
+//	unlock := km.Lock(userID)
+//	defer unlock()
+func (km *KeyedMutex[K]) Lock(k K) (unlock func()) {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = make(map[K]*refMutex)
+	}
+	e, ok := km.locks[k]
+	if !ok {
+		e = &refMutex{}
+		km.locks[k] = e
+	}
+	e.refs++ // count ourselves before blocking so the entry can't be deleted
+	km.mu.Unlock()
+
+	e.mu.Lock()
+
+	return func() {
+		e.mu.Unlock()
+
+		km.mu.Lock()
+		e.refs--
+		if e.refs == 0 {
+			delete(km.locks, k)
+		}
+		km.mu.Unlock()
+	}
+}
+
+// demoKeyedMutex shows that work on the same key is serialised while work on
+// different keys overlaps.
+func demoKeyedMutex() {
+	var km KeyedMutex[string]
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for _, key := range []string{"alice", "alice", "bob"} {
+		wg.Add(1)
+		go func(k string) {
+			defer wg.Done()
+			unlock := km.Lock(k)
+			defer unlock()
+			fmt.Printf("  %-5s: locked at +%v\n", k, time.Since(start).Round(10*time.Millisecond))
+			time.Sleep(50 * time.Millisecond)
+		}(key)
+	}
+	wg.Wait()
+
+	fmt.Printf("  entries left in the map: %d\n", len(km.locks))
+}
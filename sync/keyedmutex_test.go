@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestKeyedMutexSameKeySerializes checks that two holders of the same key are
+// never inside the critical section at the same time.
+func TestKeyedMutexSameKeySerializes(t *testing.T) {
+	var km KeyedMutex[string]
+	var inside, peak int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := km.Lock("same")
+			defer unlock()
+
+			n := atomic.AddInt32(&inside, 1)
+			if n > atomic.LoadInt32(&peak) {
+				atomic.StoreInt32(&peak, n)
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&inside, -1)
+		}()
+	}
+	wg.Wait()
+
+	if peak != 1 {
+		t.Errorf("peak holders of the same key = %d; want 1", peak)
+	}
+}
+
+// TestKeyedMutexDifferentKeysConcurrent checks that holding one key does not
+// block another key.
+func TestKeyedMutexDifferentKeysConcurrent(t *testing.T) {
+	var km KeyedMutex[int]
+
+	unlockA := km.Lock(1)
+	defer unlockA()
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock := km.Lock(2)
+		unlock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Lock(2) blocked while only key 1 was held")
+	}
+}
+
+// TestKeyedMutexNoGrowth checks that entries are removed once every holder
+// and waiter has unlocked.
+func TestKeyedMutexNoGrowth(t *testing.T) {
+	var km KeyedMutex[int]
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			unlock := km.Lock(k % 10) // contention on 10 keys
+			unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	km.mu.Lock()
+	n := len(km.locks)
+	km.mu.Unlock()
+	if n != 0 {
+		t.Errorf("map holds %d entries after all unlocks; want 0", n)
+	}
+}
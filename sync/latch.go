@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CountDownLatch is a one-shot gate that opens after a fixed number of
+// CountDown calls. Unlike WaitGroup, the count is set once at construction
+// (no Add racing with Wait) and Wait takes a context, so a waiter can give up.
+//
+// Once the count reaches zero the latch stays open forever: every current and
+// future Wait returns nil immediately.
+type CountDownLatch struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{} // closed when count reaches zero
+}
+
+// NewLatch returns a latch that opens after n calls to CountDown. A latch
+// created with n <= 0 is already open.
+func NewLatch(n int) *CountDownLatch {
+	l := &CountDownLatch{count: n, done: make(chan struct{})}
+	if n <= 0 {
+		l.count = 0
+		close(l.done)
+	}
+	return l
+}
+
+// CountDown decrements the count, opening the latch when it reaches zero.
+// Calling it on an already open latch is a bug (more CountDowns than the
+// initial n) and panics.
+func (l *CountDownLatch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count == 0 {
+		panic("sync: CountDown called on an open latch (count would go negative)")
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the number of CountDown calls still needed.
+func (l *CountDownLatch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Wait blocks until the latch opens (returns nil) or ctx is done (returns
+// ctx.Err()). Giving up does not affect the latch or other waiters.
+func (l *CountDownLatch) Wait(ctx context.Context) error {
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// demoLatch waits for three services to report ready, first with enough time
+// and then with a deadline that expires before the slowest one.
+func demoLatch() {
+	start := func(latch *CountDownLatch, delays ...time.Duration) {
+		for i, d := range delays {
+			go func(id int, d time.Duration) {
+				time.Sleep(d)
+				fmt.Printf("  service %d ready after %v\n", id, d)
+				latch.CountDown()
+			}(i+1, d)
+		}
+	}
+
+	latch := NewLatch(3)
+	start(latch, 10*time.Millisecond, 30*time.Millisecond, 20*time.Millisecond)
+	err := latch.Wait(context.Background())
+	fmt.Printf("  Wait → %v (all services up)\n", err)
+
+	latch2 := NewLatch(2)
+	start(latch2, 10*time.Millisecond, 200*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = latch2.Wait(ctx)
+	fmt.Printf("  Wait with 50ms deadline → %v (count still %d)\n", err, latch2.Count())
+}
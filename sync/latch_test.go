@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLatchOpensAfterN checks that Wait stays blocked until exactly n
+// CountDowns have happened, then returns nil.
+func TestLatchOpensAfterN(t *testing.T) {
+	const n = 3
+	latch := NewLatch(n)
+
+	errc := make(chan error, 1)
+	go func() { errc <- latch.Wait(context.Background()) }()
+
+	for i := 0; i < n; i++ {
+		select {
+		case err := <-errc:
+			t.Fatalf("Wait returned %v after %d CountDowns; want it blocked until %d", err, i, n)
+		case <-time.After(10 * time.Millisecond):
+		}
+		latch.CountDown()
+	}
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("got %v; want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after the final CountDown")
+	}
+
+	// The latch stays open for later waiters.
+	if err := latch.Wait(context.Background()); err != nil {
+		t.Errorf("second Wait = %v; want nil", err)
+	}
+}
+
+// TestLatchWaitCancelled checks that Wait returns ctx.Err() when the context
+// is cancelled before the count reaches zero.
+func TestLatchWaitCancelled(t *testing.T) {
+	latch := NewLatch(2)
+	latch.CountDown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- latch.Wait(ctx) }()
+
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v; want context.Canceled", err)
+	}
+	if c := latch.Count(); c != 1 {
+		t.Errorf("Count() = %d; want 1 (cancellation must not touch the count)", c)
+	}
+}
+
+// TestLatchCountDownBelowZeroPanics checks that an extra CountDown panics.
+func TestLatchCountDownBelowZeroPanics(t *testing.T) {
+	latch := NewLatch(1)
+	latch.CountDown()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("CountDown on an open latch did not panic")
+		}
+	}()
+	latch.CountDown()
+}
@@ -27,11 +27,26 @@ func main() {
 	section("sync.Map")
 	demoSyncMap()
 
+	section("ShardedMap — mapa concurrente por shards, LoadOrCompute")
+	demoShardedMap()
+
+	section("SWRCache — stale-while-revalidate con singleflight")
+	demoSWRCache()
+
 	section("sync/atomic — counters & CAS")
 	demoAtomic()
 
 	section("sync/atomic — Value")
 	demoAtomicValue()
+
+	section("KeyedMutex — un lock por clave")
+	demoKeyedMutex()
+
+	section("CountDownLatch — WaitGroup con cuenta fija y Wait(ctx)")
+	demoLatch()
+
+	section("CyclicBarrier — sincronización por fases")
+	demoBarrier()
 }
 
 func section(title string) {
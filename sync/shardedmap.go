@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync"
+)
+
+// ShardedMap is a concurrent map split into independently locked shards. A
+// single Mutex around one map serialises every goroutine; with N shards,
+// operations on keys in different shards proceed in parallel.
+//
+// Unlike sync.Map it is typed, and LoadOrCompute builds the default only when
+// the key is missing (sync.Map.LoadOrStore needs the value precomputed, even
+// if it ends up discarded).
+type ShardedMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []shard[K, V]
+}
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewShardedMap returns an empty map with n shards (at least 1).
+func NewShardedMap[K comparable, V any](n int) *ShardedMap[K, V] {
+	if n < 1 {
+		n = 1
+	}
+	sm := &ShardedMap[K, V]{seed: maphash.MakeSeed(), shards: make([]shard[K, V], n)}
+	for i := range sm.shards {
+		sm.shards[i].m = make(map[K]V)
+	}
+	return sm
+}
+
+func (sm *ShardedMap[K, V]) shardFor(k K) *shard[K, V] {
+	h := maphash.Comparable(sm.seed, k)
+	return &sm.shards[h%uint64(len(sm.shards))]
+}
+
+// Load returns the value for k and whether it was present.
+func (sm *ShardedMap[K, V]) Load(k K) (V, bool) {
+	s := sm.shardFor(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return v, ok
+}
+
+// Store sets the value for k.
+func (sm *ShardedMap[K, V]) Store(k K, v V) {
+	s := sm.shardFor(k)
+	s.mu.Lock()
+	s.m[k] = v
+	s.mu.Unlock()
+}
+
+// Delete removes k.
+func (sm *ShardedMap[K, V]) Delete(k K) {
+	s := sm.shardFor(k)
+	s.mu.Lock()
+	delete(s.m, k)
+	s.mu.Unlock()
+}
+
+// Len returns the number of entries. Shards are counted one at a time, so
+// under concurrent writes the total is approximate.
+func (sm *ShardedMap[K, V]) Len() int {
+	n := 0
+	for i := range sm.shards {
+		s := &sm.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// LoadOrCompute returns the existing value for k (loaded == true) or, if k
+// is absent, stores and returns compute() (loaded == false).
+//
+// compute runs at most once per missing key, however many goroutines race on
+// it: it runs while holding k's shard lock, and every other caller then finds
+// the stored value. The price is that compute blocks other operations on the
+// same shard, so it should be quick.
+func (sm *ShardedMap[K, V]) LoadOrCompute(k K, compute func() V) (v V, loaded bool) {
+	s := sm.shardFor(k)
+
+	// Fast path: most calls hit an existing key and only need a read lock.
+	s.mu.RLock()
+	v, ok := s.m[k]
+	s.mu.RUnlock()
+	if ok {
+		return v, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[k]; ok { // someone computed it between the two locks
+		return v, true
+	}
+	v = compute()
+	s.m[k] = v
+	return v, false
+}
+
+// demoShardedMap has ten goroutines ask for the same expensive config value;
+// only the first computes it.
+func demoShardedMap() {
+	cache := NewShardedMap[string, string](8)
+
+	var computed int
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.LoadOrCompute("config", func() string {
+				computed++ // safe: runs under the shard lock, at most once
+				return "loaded-from-disk"
+			})
+		}()
+	}
+	wg.Wait()
+
+	v, _ := cache.Load("config")
+	fmt.Printf("  10 goroutines → compute ran %d time(s), value=%q\n", computed, v)
+}
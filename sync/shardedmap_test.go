@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestLoadOrComputeOnce has many goroutines LoadOrCompute the same missing
+// key at once and checks that compute ran exactly once and every caller got
+// the same value.
+func TestLoadOrComputeOnce(t *testing.T) {
+	const goroutines = 100
+
+	m := NewShardedMap[string, *int](4)
+	var calls int64
+
+	start := make(chan struct{})
+	results := make([]*int, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start // release all goroutines together to maximise contention
+			results[i], _ = m.LoadOrCompute("key", func() *int {
+				atomic.AddInt64(&calls, 1)
+				v := 42
+				return &v // a fresh pointer per call exposes duplicate computes
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("compute ran %d times; want 1", calls)
+	}
+	for i, p := range results {
+		if p != results[0] {
+			t.Fatalf("goroutine %d got %p; want %p (same value for everyone)", i, p, results[0])
+		}
+	}
+}
+
+// TestShardedMapBasics checks Store/Load/Delete/Len and the loaded flag.
+func TestShardedMapBasics(t *testing.T) {
+	m := NewShardedMap[int, string](3)
+	for i := 0; i < 10; i++ {
+		m.Store(i, "v")
+	}
+	m.Delete(3)
+
+	if n := m.Len(); n != 9 {
+		t.Errorf("Len() = %d; want 9", n)
+	}
+	if _, ok := m.Load(3); ok {
+		t.Error("Load(3) found a deleted key")
+	}
+	if v, loaded := m.LoadOrCompute(5, func() string { return "new" }); !loaded || v != "v" {
+		t.Errorf("LoadOrCompute(existing) = (%q, %v); want (\"v\", true)", v, loaded)
+	}
+	if v, loaded := m.LoadOrCompute(3, func() string { return "new" }); loaded || v != "new" {
+		t.Errorf("LoadOrCompute(missing) = (%q, %v); want (\"new\", false)", v, loaded)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SWRCache is a stale-while-revalidate cache. Each entry has two ages:
+//
+//	age < softTTL            fresh: served from the cache
+//	softTTL ≤ age < hardTTL  stale: served immediately, refreshed in the background
+//	age ≥ hardTTL (or miss)  expired: the caller waits for a refetch
+//
+// Readers only wait when there is nothing acceptable to serve. A failed
+// background refresh keeps the stale value until hardTTL — graceful
+// degradation when the backend is down.
+//
+// Concurrent fetches of the same key are collapsed into one (singleflight),
+// whether they come from blocked readers or background refreshes.
+type SWRCache[K comparable, V any] struct {
+	softTTL, hardTTL time.Duration
+	now              func() time.Time // overridable in tests
+
+	mu      sync.Mutex
+	entries map[K]swrEntry[V]
+	flights map[K]*flight[V] // in-progress fetches; guarded by mu
+}
+
+type swrEntry[V any] struct {
+	value     V
+	fetchedAt time.Time
+}
+
+// flight is one in-progress fetch shared by every caller of the same key.
+type flight[V any] struct {
+	done  chan struct{} // closed once value and err are set
+	value V
+	err   error
+}
+
+// NewSWRCache returns a cache serving entries fresh for softTTL and stale
+// (while revalidating) until hardTTL.
+func NewSWRCache[K comparable, V any](softTTL, hardTTL time.Duration) *SWRCache[K, V] {
+	return &SWRCache[K, V]{
+		softTTL: softTTL,
+		hardTTL: hardTTL,
+		now:     time.Now,
+		entries: make(map[K]swrEntry[V]),
+		flights: make(map[K]*flight[V]),
+	}
+}
+
+// Get returns the value for k, calling fetch as described on SWRCache.
+// fetch runs detached from ctx (context.WithoutCancel), because its result
+// is shared with other callers; ctx only bounds how long this caller waits.
+func (c *SWRCache[K, V]) Get(ctx context.Context, k K, fetch func(ctx context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	age := c.now().Sub(e.fetchedAt)
+
+	switch {
+	case ok && age < c.softTTL: // fresh
+		c.mu.Unlock()
+		return e.value, nil
+
+	case ok && age < c.hardTTL: // stale: serve now, refresh behind
+		c.startFetchLocked(ctx, k, fetch)
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	// Missing or past hardTTL: nothing acceptable to serve, so wait.
+	f := c.startFetchLocked(ctx, k, fetch)
+	c.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// startFetchLocked returns the in-progress fetch for k, starting one if
+// there is none. c.mu must be held.
+func (c *SWRCache[K, V]) startFetchLocked(ctx context.Context, k K, fetch func(context.Context) (V, error)) *flight[V] {
+	if f, ok := c.flights[k]; ok {
+		return f // singleflight: join the fetch already running
+	}
+	f := &flight[V]{done: make(chan struct{})}
+	c.flights[k] = f
+
+	go func() {
+		v, err := fetch(context.WithoutCancel(ctx))
+
+		c.mu.Lock()
+		if err == nil {
+			c.entries[k] = swrEntry[V]{value: v, fetchedAt: c.now()}
+		} // on error keep the old entry: stale beats nothing
+		delete(c.flights, k)
+		c.mu.Unlock()
+
+		f.value, f.err = v, err
+		close(f.done)
+	}()
+	return f
+}
+
+// demoSWRCache shows the three states of an entry with a config value that
+// takes 30ms to fetch.
+func demoSWRCache() {
+	cache := NewSWRCache[string, string](40*time.Millisecond, 120*time.Millisecond)
+
+	version := 0
+	var vmu sync.Mutex
+	fetch := func(ctx context.Context) (string, error) {
+		time.Sleep(30 * time.Millisecond) // slow backend
+		vmu.Lock()
+		defer vmu.Unlock()
+		version++
+		return fmt.Sprintf("config-v%d", version), nil
+	}
+
+	get := func(label string) {
+		start := time.Now()
+		v, _ := cache.Get(context.Background(), "config", fetch)
+		fmt.Printf("  %-30s → %s (%dms)\n", label, v, time.Since(start).Milliseconds())
+	}
+
+	get("miss: waits for fetch")
+	get("fresh: from cache")
+	time.Sleep(50 * time.Millisecond)
+	get("stale: served, refresh starts")
+	time.Sleep(40 * time.Millisecond) // background refresh completes
+	get("fresh again")
+	time.Sleep(130 * time.Millisecond)
+	get("hard-expired: waits again")
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced, concurrency-safe time source.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func newTestSWRCache() (*SWRCache[string, int], *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	c := NewSWRCache[string, int](time.Minute, time.Hour)
+	c.now = clock.now
+	return c, clock
+}
+
+// counterFetch returns a fetch that yields 1, 2, 3… and counts its calls.
+func counterFetch(calls *int64) func(context.Context) (int, error) {
+	return func(context.Context) (int, error) {
+		return int(atomic.AddInt64(calls, 1)), nil
+	}
+}
+
+// TestSWRCacheFreshHit checks that within softTTL the cached value is served
+// without fetching again.
+func TestSWRCacheFreshHit(t *testing.T) {
+	c, clock := newTestSWRCache()
+	var calls int64
+	fetch := counterFetch(&calls)
+
+	c.Get(context.Background(), "k", fetch)
+	clock.advance(30 * time.Second)
+	v, err := c.Get(context.Background(), "k", fetch)
+
+	if v != 1 || err != nil || calls != 1 {
+		t.Errorf("got (%v, %v) after %d fetches; want (1, <nil>) after 1", v, err, calls)
+	}
+}
+
+// TestSWRCacheServesStaleAndRefreshes checks that past softTTL, concurrent
+// readers get the stale value immediately while exactly one background fetch
+// runs, and that later reads see the refreshed value.
+func TestSWRCacheServesStaleAndRefreshes(t *testing.T) {
+	c, clock := newTestSWRCache()
+	c.Get(context.Background(), "k", func(context.Context) (int, error) { return 1, nil })
+	clock.advance(2 * time.Minute) // stale, not hard-expired
+
+	release := make(chan struct{})
+	var calls int64
+	slowFetch := func(context.Context) (int, error) {
+		atomic.AddInt64(&calls, 1)
+		<-release // the refresh is in flight until we let it finish
+		return 2, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, _ := c.Get(context.Background(), "k", slowFetch); v != 1 {
+				t.Errorf("stale Get = %d; want 1 without waiting", v)
+			}
+		}()
+	}
+	wg.Wait() // all returned while the fetch was still blocked
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		v, _ := c.Get(context.Background(), "k", slowFetch)
+		if v == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("refreshed value never became visible")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if calls != 1 {
+		t.Errorf("background fetch ran %d times; want 1 (singleflight)", calls)
+	}
+}
+
+// TestSWRCacheHardExpiredBlocks checks that past hardTTL Get does not serve
+// the old value but waits for and returns a fresh fetch.
+func TestSWRCacheHardExpiredBlocks(t *testing.T) {
+	c, clock := newTestSWRCache()
+	var calls int64
+	fetch := counterFetch(&calls)
+
+	c.Get(context.Background(), "k", fetch)
+	clock.advance(2 * time.Hour)
+
+	v, err := c.Get(context.Background(), "k", fetch)
+	if v != 2 || err != nil {
+		t.Errorf("got (%v, %v); want (2, <nil>) from a blocking refetch", v, err)
+	}
+}
+
+// TestSWRCacheWaitHonoursContext checks that a caller blocked on a miss gives
+// up when its context ends.
+func TestSWRCacheWaitHonoursContext(t *testing.T) {
+	c, _ := newTestSWRCache()
+	block := make(chan struct{})
+	defer close(block)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Get(ctx, "k", func(context.Context) (int, error) {
+		<-block
+		return 0, nil
+	})
+	if err != context.DeadlineExceeded {
+		t.Errorf("got %v; want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DebounceChan is demoDebounce as a reusable stage: it forwards only the
+// latest value from in once in has been quiet for the quiet duration, so a
+// burst of values becomes one value — the last of the burst.
+//
+// When in closes, a pending value is emitted at once (no point waiting for
+// silence on a closed channel) and the output closes. When ctx is done the
+// output closes and a pending value is dropped.
+func DebounceChan[T any](ctx context.Context, in <-chan T, quiet time.Duration) <-chan T {
+	out := make(chan T)
+	go debounceLoop(ctx, in, quiet, newRealTimer, out)
+	return out
+}
+
+// timerFunc starts a one-shot timer, like time.NewTimer, returning its
+// channel and Stop. Tests pass a fake to fire timers by hand.
+type timerFunc func(d time.Duration) (c <-chan time.Time, stop func() bool)
+
+func newRealTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+// debounceLoop does the work of DebounceChan with timers from newTimer. It
+// closes out when it returns.
+func debounceLoop[T any](ctx context.Context, in <-chan T, quiet time.Duration, newTimer timerFunc, out chan<- T) {
+	defer close(out)
+
+	var (
+		pending T
+		has     bool
+		fire    <-chan time.Time // nil while nothing is pending: never fires
+		stop    = func() bool { return false }
+	)
+	defer func() { stop() }()
+
+	emit := func() bool {
+		has, fire = false, nil
+		select {
+		case out <- pending:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				if has {
+					emit()
+				}
+				return
+			}
+			// A new value restarts the quiet period. The old timer's channel
+			// is dropped, so it can't fire for this value even if Stop lost
+			// the race.
+			stop()
+			pending, has = v, true
+			fire, stop = newTimer(quiet)
+
+		case <-fire:
+			if !emit() {
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// demoDebounceChan runs the same event timeline as demoDebounce through
+// DebounceChan: two bursts come out as two values.
+func demoDebounceChan() {
+	events := []time.Duration{0, 30, 60, 90, 250, 280} // ms after start
+	start := time.Now()
+
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for i, d := range events {
+			time.Sleep(d*time.Millisecond - time.Since(start))
+			in <- fmt.Sprintf("event-%d", i+1)
+		}
+		time.Sleep(200 * time.Millisecond) // let the last burst settle before closing
+	}()
+
+	for v := range DebounceChan(context.Background(), in, 120*time.Millisecond) {
+		fmt.Printf("  %s emitted at +%v\n", v, time.Since(start).Round(10*time.Millisecond))
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTimers hands out timers that fire only when the test says so.
+type fakeTimers struct {
+	mu      sync.Mutex
+	chans   []chan time.Time
+	stopped []bool
+}
+
+func (f *fakeTimers) new(d time.Duration) (<-chan time.Time, func() bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i := len(f.chans)
+	f.chans = append(f.chans, make(chan time.Time, 1))
+	f.stopped = append(f.stopped, false)
+	return f.chans[i], func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		was := f.stopped[i]
+		f.stopped[i] = true
+		return !was
+	}
+}
+
+// waitTimers blocks until n timers have been started.
+func (f *fakeTimers) waitTimers(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		f.mu.Lock()
+		got := len(f.chans)
+		f.mu.Unlock()
+		if got >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d timers started; want %d", got, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (f *fakeTimers) fire(i int) {
+	f.mu.Lock()
+	ch := f.chans[i]
+	f.mu.Unlock()
+	ch <- time.Time{}
+}
+
+// TestDebounceEmitsLastOfBurst feeds a burst, fires the quiet-period timer
+// and checks that only the burst's last value comes out, that every earlier
+// timer was stopped, and that closing the input flushes a pending value.
+func TestDebounceEmitsLastOfBurst(t *testing.T) {
+	clock := &fakeTimers{}
+	in := make(chan int)
+	out := make(chan int)
+	go debounceLoop(context.Background(), in, time.Second, clock.new, out)
+
+	for v := 1; v <= 3; v++ {
+		in <- v
+	}
+	clock.waitTimers(t, 3)
+	clock.fire(2)
+
+	select {
+	case v := <-out:
+		if v != 3 {
+			t.Errorf("emitted %d; want 3 (the last of the burst)", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("nothing emitted after the quiet period")
+	}
+	clock.mu.Lock()
+	if !clock.stopped[0] || !clock.stopped[1] {
+		t.Errorf("stopped = %v; want the first two timers stopped", clock.stopped)
+	}
+	clock.mu.Unlock()
+
+	in <- 4
+	close(in) // flushes 4 without waiting for its timer
+	if v, ok := <-out; !ok || v != 4 {
+		t.Errorf("after close got %d, %v; want 4, true", v, ok)
+	}
+	if v, ok := <-out; ok {
+		t.Errorf("got %d after the flush; want the output closed", v)
+	}
+}
+
+// TestDebounceChanRealTimer checks the wrapper end to end with a short quiet
+// period: two bursts separated by silence produce two values.
+func TestDebounceChanRealTimer(t *testing.T) {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		in <- "a1"
+		in <- "a2"
+		time.Sleep(100 * time.Millisecond)
+		in <- "b1"
+		time.Sleep(100 * time.Millisecond)
+	}()
+
+	var got []string
+	for v := range DebounceChan(context.Background(), in, 30*time.Millisecond) {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != "a2" || got[1] != "b1" {
+		t.Errorf("got %v; want [a2 b1]", got)
+	}
+}
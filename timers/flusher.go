@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Flusher batches items and hands them to a flush function either when the
+// batch reaches maxSize or when the interval elapses — whichever comes first.
+// It is the usual shape for writing logs, metrics or DB rows in bulk: full
+// batches go out immediately, a trickle still goes out every interval.
+//
+// The interval loop is Run(ctx); run it in its own goroutine. Cancelling ctx
+// (e.g. on SIGTERM) stops the ticker and does one final flush, so items
+// buffered at shutdown are not lost. Close does the same without a context.
+type Flusher[T any] struct {
+	maxSize  int
+	interval time.Duration
+	flush    func([]T)
+
+	mu  sync.Mutex
+	buf []T
+
+	// flushMu serialises flush calls, so batches arrive in the order their
+	// items were added even when Add and the ticker flush concurrently.
+	flushMu sync.Mutex
+
+	stop     chan struct{} // closed by Close to end Run
+	stopOnce sync.Once
+}
+
+// NewFlusher returns a Flusher that calls flush with batches of at most
+// maxSize items, at least every interval while Run is running.
+func NewFlusher[T any](maxSize int, interval time.Duration, flush func([]T)) *Flusher[T] {
+	return &Flusher[T]{
+		maxSize:  maxSize,
+		interval: interval,
+		flush:    flush,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Add buffers v and flushes synchronously if the batch is now full.
+func (f *Flusher[T]) Add(v T) {
+	f.mu.Lock()
+	f.buf = append(f.buf, v)
+	full := len(f.buf) >= f.maxSize
+	f.mu.Unlock()
+
+	if full {
+		f.Flush()
+	}
+}
+
+// Flush hands the buffered items, if any, to the flush function now.
+func (f *Flusher[T]) Flush() {
+	f.flushMu.Lock()
+	defer f.flushMu.Unlock()
+
+	f.mu.Lock()
+	batch := f.buf
+	f.buf = nil
+	f.mu.Unlock()
+
+	if len(batch) > 0 {
+		f.flush(batch)
+	}
+}
+
+// Run flushes every interval until ctx is cancelled or Close is called. On
+// cancellation it stops the ticker and flushes whatever is still buffered
+// before returning.
+func (f *Flusher[T]) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.Flush()
+		case <-ctx.Done():
+			f.Flush() // final flush: the partial batch must not be lost
+			return
+		case <-f.stop:
+			return // Close does the final flush itself
+		}
+	}
+}
+
+// Close stops Run, if it is running, and flushes the remaining items.
+func (f *Flusher[T]) Close() {
+	f.stopOnce.Do(func() { close(f.stop) })
+	f.Flush()
+}
+
+func demoFlusher() {
+	start := time.Now()
+	f := NewFlusher(3, 50*time.Millisecond, func(batch []string) {
+		fmt.Printf("    +%3dms flush %v\n", time.Since(start).Milliseconds(), batch)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+
+	for _, ev := range []string{"a", "b", "c", "d"} { // a,b,c: full batch
+		f.Add(ev)
+	}
+	time.Sleep(70 * time.Millisecond) // d goes out on the first tick
+
+	f.Add("e") // buffered when the "signal" arrives
+	fmt.Println("  cancelling context with 1 item buffered")
+	cancel()
+	<-done
+}
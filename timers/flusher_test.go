@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder collects the batches a Flusher emits.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(b []int) {
+	r.mu.Lock()
+	r.batches = append(r.batches, b)
+	r.mu.Unlock()
+}
+
+func (r *batchRecorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+// TestFlusherRunFlushesOnCancel cancels Run's context with a partial batch
+// buffered and checks that Run returns after a final flush of those items.
+func TestFlusherRunFlushesOnCancel(t *testing.T) {
+	var rec batchRecorder
+	f := NewFlusher(10, time.Hour, rec.flush) // neither size nor interval triggers
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		f.Run(ctx)
+		close(done)
+	}()
+
+	f.Add(1)
+	f.Add(2)
+	if got := rec.get(); len(got) != 0 {
+		t.Fatalf("flushed %v before cancel; want nothing", got)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	if got, want := rec.get(), [][]int{{1, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got batches %v; want %v", got, want)
+	}
+}
+
+// TestFlusherSizeAndInterval checks that a full batch flushes at once and a
+// partial one on the next tick.
+func TestFlusherSizeAndInterval(t *testing.T) {
+	var rec batchRecorder
+	f := NewFlusher(2, 20*time.Millisecond, rec.flush)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go f.Run(ctx)
+
+	f.Add(1)
+	f.Add(2) // full → flushed synchronously
+	if got, want := rec.get(), [][]int{{1, 2}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after full batch: got %v; want %v", got, want)
+	}
+
+	f.Add(3)
+	deadline := time.Now().Add(time.Second)
+	for len(rec.get()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got, want := rec.get(), [][]int{{1, 2}, {3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after tick: got %v; want %v", got, want)
+	}
+}
+
+// TestFlusherClose checks that Close flushes what is buffered and stops Run.
+func TestFlusherClose(t *testing.T) {
+	var rec batchRecorder
+	f := NewFlusher(10, time.Hour, rec.flush)
+
+	done := make(chan struct{})
+	go func() {
+		f.Run(context.Background())
+		close(done)
+	}()
+
+	f.Add(7)
+	f.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after Close")
+	}
+	if got, want := rec.get(), [][]int{{7}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
@@ -33,6 +33,9 @@ func main() {
 	section("Patrón: debounce")
 	demoDebounce()
 
+	section("Patrón: debounce como canal (DebounceChan)")
+	demoDebounceChan()
+
 	section("Patrón: rate limiter")
 	demoRateLimit()
 
@@ -41,6 +44,12 @@ func main() {
 
 	section("Patrón: tarea periódica cancelable")
 	demoPeriodic()
+
+	section("Patrón: batching con flush por tamaño o intervalo")
+	demoFlusher()
+
+	section("Patrón: scheduler con min-heap (un solo timer)")
+	demoScheduler()
 }
 
 func section(title string) {
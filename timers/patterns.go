@@ -81,6 +81,31 @@ func demoRateLimit() {
 	}
 }
 
+// rng supplies the backoff jitter. Using a package-level *rand.Rand instead of
+// the global math/rand functions lets tests inject a fixed seed. It is not
+// safe for concurrent use; the demos only touch it from one goroutine.
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// jitteredBackoff returns how long to wait before retry number attempt
+// (1-based): baseDelay doubled per previous attempt, plus up to 50 % of that
+// as random jitter drawn from r, capped at maxDelay.
+func jitteredBackoff(r *rand.Rand, attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := baseDelay << (attempt - 1) // exponential back-off
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay // also guards against shift overflow
+	}
+
+	// Jitter: add up to 50 % of delay as random noise.
+	wait := delay
+	if half := int64(delay / 2); half > 0 {
+		wait += time.Duration(r.Int63n(half))
+	}
+	if wait > maxDelay {
+		wait = maxDelay
+	}
+	return wait
+}
+
 // demoRetryBackoff shows exponential backoff with jitter for retrying a
 // failing operation. The delay doubles on each failure, capped at maxDelay.
 //
@@ -95,7 +120,6 @@ func demoRetryBackoff() {
 	)
 
 	attempt := 0
-	delay := baseDelay
 
 	for {
 		attempt++
@@ -109,19 +133,12 @@ func demoRetryBackoff() {
 				return
 			}
 
-			// Jitter: add up to 50 % of delay as random noise.
-			jitter := time.Duration(rand.Int63n(int64(delay / 2)))
-			wait := delay + jitter
-			if wait > maxDelay {
-				wait = maxDelay
-			}
+			wait := jitteredBackoff(rng, attempt, baseDelay, maxDelay)
 			fmt.Printf("  retrying in %v\n", wait.Round(time.Millisecond))
 
 			timer := time.NewTimer(wait)
 			<-timer.C
 			timer.Stop()
-
-			delay *= 2 // exponential back-off
 		} else {
 			fmt.Println(" success")
 			return
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestJitteredBackoffReproducible checks that a fixed seed yields the same
+// backoff sequence and that each wait respects the exponential bounds.
+func TestJitteredBackoffReproducible(t *testing.T) {
+	const baseDelay, maxDelay = 20 * time.Millisecond, 200 * time.Millisecond
+
+	a := rand.New(rand.NewSource(7))
+	b := rand.New(rand.NewSource(7))
+
+	for attempt := 1; attempt <= 8; attempt++ {
+		wa := jitteredBackoff(a, attempt, baseDelay, maxDelay)
+		wb := jitteredBackoff(b, attempt, baseDelay, maxDelay)
+		if wa != wb {
+			t.Fatalf("attempt %d: %s != %s with the same seed", attempt, wa, wb)
+		}
+
+		delay := baseDelay << (attempt - 1)
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		if wa < delay || wa > maxDelay || wa > delay+delay/2 {
+			t.Errorf("attempt %d: wait %s outside [%s, min(%s, %s)]",
+				attempt, wa, delay, delay+delay/2, maxDelay)
+		}
+	}
+}
+
+// TestJitteredBackoffSeedsDiffer is a sanity check that the jitter actually
+// depends on the injected source.
+func TestJitteredBackoffSeedsDiffer(t *testing.T) {
+	seq := func(seed int64) []time.Duration {
+		r := rand.New(rand.NewSource(seed))
+		out := make([]time.Duration, 5)
+		for i := range out {
+			out[i] = jitteredBackoff(r, 3, time.Second, time.Hour)
+		}
+		return out
+	}
+
+	a, b := seq(1), seq(2)
+	for i := range a {
+		if a[i] != b[i] {
+			return
+		}
+	}
+	t.Errorf("seeds 1 and 2 produced identical sequences %v", a)
+}
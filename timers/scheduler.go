@@ -0,0 +1,175 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Scheduler runs callbacks at given instants using one goroutine and one
+// timer, whatever the number of pending callbacks. time.AfterFunc per task
+// costs a runtime timer each; here pending tasks are just entries in a
+// min-heap ordered by due time, and the single timer is always armed for the
+// earliest one.
+//
+// Callbacks run one at a time on the scheduler goroutine, so they should be
+// short (or start their own goroutine); a slow callback delays the next ones.
+type Scheduler struct {
+	mu    sync.Mutex
+	tasks taskHeap
+	seq   uint64 // tie-breaker: equal due times fire in Schedule order
+
+	wake chan struct{} // a new earliest task was pushed; cap 1
+	stop chan struct{}
+	done chan struct{}
+}
+
+type scheduledTask struct {
+	at    time.Time
+	seq   uint64
+	fn    func()
+	index int // position in the heap; -1 once popped or cancelled
+}
+
+// NewScheduler starts the scheduler goroutine. Call Stop to end it.
+func NewScheduler() *Scheduler {
+	s := &Scheduler{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Schedule arranges for fn to run at (or, if at is in the past, right after)
+// the given time. The returned cancel func removes the task and reports
+// whether it did so before the task started, like time.Timer.Stop.
+func (s *Scheduler) Schedule(at time.Time, fn func()) (cancel func() bool) {
+	s.mu.Lock()
+	t := &scheduledTask{at: at, seq: s.seq, fn: fn}
+	s.seq++
+	heap.Push(&s.tasks, t)
+	earliest := t.index == 0
+	s.mu.Unlock()
+
+	if earliest {
+		select { // re-arm the timer; a pending wake-up already covers it
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if t.index < 0 {
+			return false // already run (or running) or already cancelled
+		}
+		heap.Remove(&s.tasks, t.index)
+		return true
+	}
+}
+
+// Stop ends the scheduler goroutine and waits for it. Pending tasks are
+// discarded; a callback already running finishes first.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		var next *scheduledTask
+		wait := time.Duration(-1) // -1: nothing scheduled, wait for a wake-up
+		if len(s.tasks) > 0 {
+			next = s.tasks[0]
+			wait = time.Until(next.at)
+			if wait <= 0 {
+				heap.Pop(&s.tasks)
+			}
+		}
+		s.mu.Unlock()
+
+		if next != nil && wait <= 0 {
+			next.fn()
+			continue
+		}
+
+		var timer *time.Timer
+		var timerC <-chan time.Time // nil when idle: blocks forever in select
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			timerC = timer.C
+		}
+		select {
+		case <-timerC: // the earliest task is due
+		case <-s.wake: // an earlier task was scheduled
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop() // no-op if it fired; frees it if we were woken early
+		}
+	}
+}
+
+// taskHeap implements heap.Interface, ordered by due time then by seq.
+type taskHeap []*scheduledTask
+
+func (h taskHeap) Len() int { return len(h) }
+func (h taskHeap) Less(i, j int) bool {
+	if !h[i].at.Equal(h[j].at) {
+		return h[i].at.Before(h[j].at)
+	}
+	return h[i].seq < h[j].seq
+}
+func (h taskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *taskHeap) Push(x any) {
+	t := x.(*scheduledTask)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+func (h *taskHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil // let the GC reclaim the task
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
+
+func demoScheduler() {
+	s := NewScheduler()
+	defer s.Stop()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, d := range []time.Duration{60, 20, 40} {
+		d := d * time.Millisecond
+		wg.Add(1)
+		s.Schedule(start.Add(d), func() {
+			defer wg.Done()
+			fmt.Printf("  +%3dms fired task scheduled for +%v\n", time.Since(start).Milliseconds(), d)
+		})
+	}
+
+	cancel := s.Schedule(start.Add(30*time.Millisecond), func() {
+		fmt.Println("  never printed")
+	})
+	fmt.Println("  cancelled +30ms task:", cancel())
+
+	wg.Wait()
+}
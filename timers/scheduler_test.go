@@ -0,0 +1,90 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSchedulerFiresInTimeOrder schedules callbacks out of order and checks
+// that they run ordered by due time, none early.
+func TestSchedulerFiresInTimeOrder(t *testing.T) {
+	s := NewScheduler()
+	defer s.Stop()
+
+	start := time.Now()
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	for _, ms := range []int{50, 10, 40, 20, 30} {
+		ms := ms
+		due := start.Add(time.Duration(ms) * time.Millisecond)
+		wg.Add(1)
+		s.Schedule(due, func() {
+			defer wg.Done()
+			if now := time.Now(); now.Before(due) {
+				t.Errorf("task %dms fired %v early", ms, due.Sub(now))
+			}
+			mu.Lock()
+			order = append(order, ms)
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	if want := []int{10, 20, 30, 40, 50}; !slices.Equal(order, want) {
+		t.Errorf("fired in order %v; want %v", order, want)
+	}
+}
+
+// TestSchedulerCancel cancels a task before it is due and checks that it
+// never runs while its neighbours do, and that cancelling twice reports false.
+func TestSchedulerCancel(t *testing.T) {
+	s := NewScheduler()
+	defer s.Stop()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	s.Schedule(start.Add(10*time.Millisecond), wg.Done)
+
+	cancelled := make(chan struct{})
+	cancel := s.Schedule(start.Add(20*time.Millisecond), func() { close(cancelled) })
+
+	s.Schedule(start.Add(30*time.Millisecond), wg.Done)
+
+	if !cancel() {
+		t.Fatal("cancel() = false before the task was due; want true")
+	}
+	if cancel() {
+		t.Error("second cancel() = true; want false")
+	}
+	wg.Wait() // the 30ms task ran, so the 20ms slot has passed
+
+	select {
+	case <-cancelled:
+		t.Error("cancelled task ran")
+	default:
+	}
+}
+
+// TestSchedulerEarlierTaskPreempts checks that scheduling a task earlier than
+// the one the timer is armed for re-arms it.
+func TestSchedulerEarlierTaskPreempts(t *testing.T) {
+	s := NewScheduler()
+	defer s.Stop()
+
+	s.Schedule(time.Now().Add(time.Hour), func() {})
+
+	fired := make(chan struct{})
+	s.Schedule(time.Now().Add(10*time.Millisecond), func() { close(fired) })
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("10ms task did not fire while an hour-long one was pending")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/marcodamonte/concurrency/worker-pool
 
-go 1.18
+go 1.21
@@ -7,7 +7,6 @@ import (
 	"log"
 	"math/rand"
 	"os"
-	"os/signal"
 	"syscall"
 	"time"
 
@@ -24,54 +23,62 @@ func main() {
 		Logger:          logger,
 	})
 
-	// ── Graceful shutdown on SIGINT / SIGTERM ────────────────────────────────
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
-	// ── Submit jobs until the signal fires ──────────────────────────────────
-	go func() {
-		for id := 1; ; id++ {
-			select {
-			case <-ctx.Done():
-				return
-			default:
+	// ── Submit jobs until SIGINT / SIGTERM, then shut down gracefully ────────
+	err := RunUntilSignal(
+		func(ctx context.Context) error {
+			go submitOrders(ctx, pool)
+			return nil
+		},
+		func() error {
+			fmt.Println()
+			logger.Println("[main] signal received — shutting down pool")
+
+			err := pool.Shutdown()
+			if errors.Is(err, workerpool.ErrShutdownTimeout) {
+				logger.Println("[main] some jobs were cancelled (shutdown timeout exceeded)")
 			}
 
-			jobID := id // capture for closure
-			err := pool.Submit(ctx, func(jobCtx context.Context) error {
-				return processOrder(jobCtx, jobID)
-			})
-
-			switch {
-			case errors.Is(err, workerpool.ErrPoolClosed):
-				return
-			case err != nil:
-				// Submit was cancelled because the signal fired mid-wait.
-				return
-			}
+			m := pool.Metrics()
+			logger.Printf("[main] metrics: submitted=%d started=%d succeeded=%d failed=%d dropped=%d",
+				m.Submitted, m.Started, m.Succeeded, m.Failed, m.Dropped)
+			return err
+		},
+		os.Interrupt, syscall.SIGTERM,
+	)
+	if err != nil && !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		logger.Fatalf("[main] %v", err)
+	}
+}
 
-			// Pace submissions so the demo is readable.
-			select {
-			case <-time.After(80 * time.Millisecond):
-			case <-ctx.Done():
-			}
+// submitOrders submits an order-processing job every 80 ms until ctx is
+// cancelled or the pool stops accepting work.
+func submitOrders(ctx context.Context, pool *workerpool.Pool) {
+	for id := 1; ; id++ {
+		select {
+		case <-ctx.Done():
+			return
+		default:
 		}
-	}()
 
-	// ── Wait for signal ──────────────────────────────────────────────────────
-	<-ctx.Done()
-	stop() // release signal resources
-
-	fmt.Println()
-	logger.Println("[main] signal received — shutting down pool")
+		jobID := id // capture for closure
+		err := pool.Submit(ctx, func(jobCtx context.Context) error {
+			return processOrder(jobCtx, jobID)
+		})
+
+		switch {
+		case errors.Is(err, workerpool.ErrPoolClosed):
+			return
+		case err != nil:
+			// Submit was cancelled because the signal fired mid-wait.
+			return
+		}
 
-	if err := pool.Shutdown(); errors.Is(err, workerpool.ErrShutdownTimeout) {
-		logger.Println("[main] some jobs were cancelled (shutdown timeout exceeded)")
+		// Pace submissions so the demo is readable.
+		select {
+		case <-time.After(80 * time.Millisecond):
+		case <-ctx.Done():
+		}
 	}
-
-	m := pool.Metrics()
-	logger.Printf("[main] metrics: submitted=%d started=%d succeeded=%d failed=%d dropped=%d",
-		m.Submitted, m.Started, m.Succeeded, m.Failed, m.Dropped)
 }
 
 // processOrder simulates order processing with variable latency and occasional
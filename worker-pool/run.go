@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyContext is signal.NotifyContext; tests replace it to simulate a
+// signal without sending one to the test binary.
+var notifyContext = signal.NotifyContext
+
+// RunUntilSignal captures the boilerplate every long-running main repeats:
+//
+//  1. Build a context that is cancelled when one of sigs arrives
+//     (os.Interrupt and SIGTERM if none are given).
+//  2. Run setup with that context; setup should start background work and
+//     return quickly. If it fails, its error is returned and shutdown is
+//     not called.
+//  3. Block until a signal arrives.
+//  4. Stop listening for signals — a second Ctrl-C now kills the process
+//     instead of being swallowed — and return shutdown's error.
+func RunUntilSignal(setup func(ctx context.Context) error, shutdown func() error, sigs ...os.Signal) error {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ctx, stop := notifyContext(context.Background(), sigs...)
+	defer stop()
+
+	if err := setup(ctx); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	stop() // release signal resources
+
+	return shutdown()
+}
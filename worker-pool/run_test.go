@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// fakeNotify replaces notifyContext for the duration of a test. It records
+// the requested signals and returns a context the test cancels to simulate a
+// signal arriving.
+func fakeNotify(t *testing.T) (fire func(), gotSigs *[]os.Signal) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var sigs []os.Signal
+
+	orig := notifyContext
+	notifyContext = func(parent context.Context, s ...os.Signal) (context.Context, context.CancelFunc) {
+		sigs = s
+		return ctx, cancel
+	}
+	t.Cleanup(func() { notifyContext = orig })
+
+	return cancel, &sigs
+}
+
+// TestRunUntilSignalRunsShutdown checks that setup runs first, that shutdown
+// runs only after the signal, and that shutdown's error is returned.
+func TestRunUntilSignalRunsShutdown(t *testing.T) {
+	fire, gotSigs := fakeNotify(t)
+	errShutdown := errors.New("drain incomplete")
+
+	setupDone := make(chan struct{})
+	var shutdownRan bool
+
+	result := make(chan error, 1)
+	go func() {
+		result <- RunUntilSignal(
+			func(ctx context.Context) error {
+				close(setupDone)
+				return nil
+			},
+			func() error {
+				shutdownRan = true
+				return errShutdown
+			},
+			os.Interrupt,
+		)
+	}()
+
+	<-setupDone
+	select {
+	case err := <-result:
+		t.Fatalf("RunUntilSignal returned %v before any signal", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	fire()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, errShutdown) {
+			t.Errorf("RunUntilSignal() = %v; want %v", err, errShutdown)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RunUntilSignal did not return after the signal")
+	}
+	if !shutdownRan {
+		t.Error("shutdown was not called")
+	}
+	if len(*gotSigs) != 1 || (*gotSigs)[0] != os.Interrupt {
+		t.Errorf("notified signals = %v; want [interrupt]", *gotSigs)
+	}
+}
+
+// TestRunUntilSignalSetupError checks that a failing setup is returned
+// immediately and shutdown is skipped.
+func TestRunUntilSignalSetupError(t *testing.T) {
+	_, gotSigs := fakeNotify(t)
+	errSetup := errors.New("bind failed")
+
+	err := RunUntilSignal(
+		func(ctx context.Context) error { return errSetup },
+		func() error {
+			t.Error("shutdown called after failed setup")
+			return nil
+		},
+	)
+	if !errors.Is(err, errSetup) {
+		t.Errorf("RunUntilSignal() = %v; want %v", err, errSetup)
+	}
+	if len(*gotSigs) != 2 {
+		t.Errorf("default signals = %v; want os.Interrupt and SIGTERM", *gotSigs)
+	}
+}
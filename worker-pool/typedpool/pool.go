@@ -0,0 +1,136 @@
+// Package typedpool is a generic front end to workerpool for map-style
+// workloads: every job applies the same function to a different input, and
+// the typed outputs are collected from one channel.
+//
+//	pool := typedpool.New(cfg, fetch) // fetch: func(ctx, url string) (Page, error)
+//	go func() {
+//		for _, url := range urls {
+//			pool.Submit(ctx, url)
+//		}
+//		pool.Shutdown()
+//	}()
+//	for r := range pool.Results() { // closed after the last worker exits
+//		...
+//	}
+//
+// Scheduling, graceful shutdown and ShutdownTimeout are workerpool's: a
+// typedpool.Pool is a workerpool.Pool whose jobs send their typed result to
+// Results before returning.
+package typedpool
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/marcodamonte/concurrency/worker-pool/workerpool"
+)
+
+// Result is the outcome of one input: the function's value, meaningful only
+// if Err is nil, or its error. Same shape as the generics module's Result[T].
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Pool runs fn over submitted inputs on a fixed set of workers.
+type Pool[In, Out any] struct {
+	inner   *workerpool.Pool
+	fn      func(context.Context, In) (Out, error)
+	results chan Result[Out]
+	once    sync.Once // closes results in the first Shutdown
+
+	// dropped counts results discarded because a forced shutdown cancelled
+	// the worker while it waited for room on Results.
+	dropped int64
+}
+
+// New starts a pool configured by cfg (see workerpool.Config) that applies fn
+// to every submitted input. Config.ResultBuffer sizes the Results channel.
+func New[In, Out any](cfg workerpool.Config, fn func(context.Context, In) (Out, error)) *Pool[In, Out] {
+	buf := cfg.ResultBuffer
+	if buf <= 0 {
+		buf = cfg.QueueSize + max(cfg.Workers, 1) // workerpool's default
+	}
+	return &Pool[In, Out]{
+		inner:   workerpool.New(cfg),
+		fn:      fn,
+		results: make(chan Result[Out], buf),
+	}
+}
+
+// Submit enqueues in, with the semantics of workerpool.Pool.Submit: it blocks
+// while the queue is full, honouring ctx, and returns ErrPoolClosed once
+// Shutdown has begun. Values stored in ctx reach fn.
+func (p *Pool[In, Out]) Submit(ctx context.Context, in In) error {
+	return p.inner.Submit(ctx, func(ctx context.Context) error {
+		out, err := p.call(ctx, in)
+		if workerpool.WillRetry(ctx, err) {
+			return err // Config.MaxRetries runs it again: no Result yet
+		}
+		r := Result[Out]{Value: out, Err: err}
+		select {
+		case p.results <- r: // room: deliver even if ctx is already done
+			return err
+		default:
+		}
+		select {
+		case p.results <- r:
+		case <-ctx.Done():
+			atomic.AddInt64(&p.dropped, 1) // forced shutdown, nobody reading
+		}
+		return err
+	})
+}
+
+// call runs fn, turning a panic into a *workerpool.PanicError as the worker
+// would, so a panicking input still yields its Result.
+func (p *Pool[In, Out]) call(ctx context.Context, in In) (out Out, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &workerpool.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return p.fn(ctx, in)
+}
+
+// Results streams one Result per input that ran, in completion order. It is
+// closed by Shutdown once every worker has exited, so ranging over it ends
+// after the last result. With Config.MaxRetries an input's Result is its last
+// attempt's: the success, or the failure the pool gave up on.
+//
+// Unlike workerpool.Pool.Results nothing is dropped while the pool runs: a
+// worker waits for room on Results, so a slow reader slows the pool down
+// (backpressure). Read it concurrently with Submit and Shutdown unless every
+// input fits in Config.ResultBuffer. Only a forced shutdown drops results,
+// counted in Metrics().ResultsDropped; inputs it kept from starting produce
+// no result and are counted in Metrics().Cancelled.
+func (p *Pool[In, Out]) Results() <-chan Result[Out] {
+	return p.results
+}
+
+// Shutdown stops the pool like workerpool.Pool.Shutdown — drain the queue,
+// force-cancel after ShutdownTimeout, returning ErrShutdownTimeout if it had
+// to — and then closes Results.
+func (p *Pool[In, Out]) Shutdown() error {
+	err := p.inner.Shutdown()
+	p.once.Do(func() { close(p.results) }) // no worker left to send
+	return err
+}
+
+// ShutdownContext is Shutdown with the drain bounded by ctx instead of
+// ShutdownTimeout, like workerpool.Pool.ShutdownContext.
+func (p *Pool[In, Out]) ShutdownContext(ctx context.Context) error {
+	err := p.inner.ShutdownContext(ctx)
+	p.once.Do(func() { close(p.results) })
+	return err
+}
+
+// Metrics returns the underlying pool's counters, with ResultsDropped
+// counting the results a forced shutdown discarded.
+func (p *Pool[In, Out]) Metrics() workerpool.Metrics {
+	m := p.inner.Metrics()
+	m.ResultsDropped += atomic.LoadInt64(&p.dropped)
+	return m
+}
@@ -0,0 +1,242 @@
+package typedpool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marcodamonte/concurrency/worker-pool/typedpool"
+	"github.com/marcodamonte/concurrency/worker-pool/workerpool"
+)
+
+// quietConfig returns a Config with the given sizes and a discarding logger.
+func quietConfig(workers, queue int) workerpool.Config {
+	return workerpool.Config{
+		Workers:         workers,
+		QueueSize:       queue,
+		ShutdownTimeout: time.Second,
+		Logger:          log.New(io.Discard, "", 0),
+	}
+}
+
+// TestResultsCarryEveryValue submits N inputs while draining Results and
+// checks that exactly N results come back, each with the right value, and
+// that Results closes after Shutdown.
+func TestResultsCarryEveryValue(t *testing.T) {
+	t.Parallel()
+
+	const n = 200
+	pool := typedpool.New(quietConfig(4, 8), func(ctx context.Context, in int) (string, error) {
+		return fmt.Sprintf("%d:%d", in, in*in), nil
+	})
+
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := pool.Submit(context.Background(), i); err != nil {
+				t.Errorf("submit %d: %v", i, err)
+			}
+		}
+		if err := pool.Shutdown(); err != nil {
+			t.Errorf("shutdown: %v", err)
+		}
+	}()
+
+	var got []string
+	for r := range pool.Results() { // ends only if Results is closed
+		if r.Err != nil {
+			t.Errorf("unexpected error: %v", r.Err)
+		}
+		got = append(got, r.Value)
+	}
+
+	want := make([]string, n)
+	for i := range want {
+		want[i] = fmt.Sprintf("%d:%d", i, i*i)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %d results %v; want %d results %v", len(got), got, n, want)
+	}
+	if m := pool.Metrics(); m.Succeeded != n || m.ResultsDropped != 0 {
+		t.Errorf("Succeeded, ResultsDropped = %d, %d; want %d, 0", m.Succeeded, m.ResultsDropped, n)
+	}
+}
+
+// TestSubmitContextValuesReachFn submits with a value in ctx and then
+// cancels that ctx, and checks fn still reads the value and runs to
+// completion: values come from the submit context, cancellation only from
+// the pool.
+func TestSubmitContextValuesReachFn(t *testing.T) {
+	t.Parallel()
+
+	type userKey struct{}
+	pool := typedpool.New(quietConfig(1, 1), func(ctx context.Context, in int) (string, error) {
+		user, _ := ctx.Value(userKey{}).(string)
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return user + ":" + strconv.Itoa(in), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), userKey{}, "ana"))
+	if err := pool.Submit(ctx, 7); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	cancel()
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	r, ok := <-pool.Results()
+	if !ok {
+		t.Fatal("Results closed without a result")
+	}
+	if r.Err != nil || r.Value != "ana:7" {
+		t.Errorf("result = %q, %v; want %q, nil", r.Value, r.Err, "ana:7")
+	}
+}
+
+// TestResultsCarryErrors checks that fn's errors come back on Results
+// next to the successful values, and that Submit after Shutdown returns
+// ErrPoolClosed.
+func TestResultsCarryErrors(t *testing.T) {
+	t.Parallel()
+
+	pool := typedpool.New(quietConfig(2, 4), func(ctx context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	for _, s := range []string{"1", "x", "3"} {
+		if err := pool.Submit(context.Background(), s); err != nil {
+			t.Fatalf("submit %q: %v", s, err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	var values []int
+	var errs int
+	for r := range pool.Results() {
+		if r.Err != nil {
+			if !errors.Is(r.Err, strconv.ErrSyntax) {
+				t.Errorf("result Err = %v; want strconv.ErrSyntax", r.Err)
+			}
+			errs++
+			continue
+		}
+		values = append(values, r.Value)
+	}
+	slices.Sort(values)
+	if !slices.Equal(values, []int{1, 3}) || errs != 1 {
+		t.Errorf("values %v, %d errors; want [1 3], 1 error", values, errs)
+	}
+
+	if err := pool.Submit(context.Background(), "4"); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("submit after shutdown = %v; want ErrPoolClosed", err)
+	}
+}
+
+// TestShutdownTimeoutClosesResults blocks both workers past ShutdownTimeout
+// with two more inputs queued, and checks Shutdown reports the forced
+// cancellation, the two running inputs yield their cancellation as Err, the
+// two queued ones are skipped, and Results closes.
+func TestShutdownTimeoutClosesResults(t *testing.T) {
+	t.Parallel()
+
+	cfg := quietConfig(2, 2)
+	cfg.ShutdownTimeout = 20 * time.Millisecond
+	started := make(chan struct{}, 2)
+	pool := typedpool.New(cfg, func(ctx context.Context, in int) (int, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	for i := 0; i < 4; i++ { // 2 running, 2 queued
+		if err := pool.Submit(context.Background(), i); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+	}
+	<-started
+	<-started
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Fatalf("shutdown = %v; want ErrShutdownTimeout", err)
+	}
+
+	n := 0
+	for r := range pool.Results() {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result Err = %v; want context.Canceled", r.Err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("got %d results; want 2", n)
+	}
+	if m := pool.Metrics(); m.Cancelled != 4 {
+		t.Errorf("Cancelled = %d; want 4", m.Cancelled)
+	}
+}
+
+// TestRetriesYieldOneResult runs inputs under MaxRetries that succeed at once,
+// succeed on a retry, or always fail, and checks each input yields exactly
+// one Result: its final attempt's.
+func TestRetriesYieldOneResult(t *testing.T) {
+	t.Parallel()
+
+	const n, maxRetries = 30, 2
+	cfg := quietConfig(3, 8)
+	cfg.MaxRetries = maxRetries
+	cfg.RetryBackoff = func(int) time.Duration { return time.Millisecond }
+
+	var mu sync.Mutex
+	attempts := make(map[int]int)
+	pool := typedpool.New(cfg, func(ctx context.Context, in int) (int, error) {
+		mu.Lock()
+		attempts[in]++
+		k := attempts[in]
+		mu.Unlock()
+		switch {
+		case in%3 == 0, in%3 == 1 && k > 1:
+			return in, nil
+		default: // in%3 == 2 always fails; in%3 == 1 fails once
+			return in, fmt.Errorf("input %d attempt %d failed", in, k)
+		}
+	})
+
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := pool.Submit(context.Background(), i); err != nil {
+				t.Errorf("submit %d: %v", i, err)
+			}
+		}
+		if err := pool.Shutdown(); err != nil {
+			t.Errorf("shutdown: %v", err)
+		}
+	}()
+
+	results := make(map[int]int)
+	for r := range pool.Results() {
+		results[r.Value]++
+		if failing := r.Value%3 == 2; failing != (r.Err != nil) {
+			t.Errorf("input %d: err = %v; want an error only for always-failing inputs", r.Value, r.Err)
+		}
+		if want := fmt.Sprintf("attempt %d failed", maxRetries+1); r.Err != nil && !strings.Contains(r.Err.Error(), want) {
+			t.Errorf("input %d: err = %v; want the last attempt's error (%s)", r.Value, r.Err, want)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if results[i] != 1 {
+			t.Errorf("input %d yielded %d results; want 1", i, results[i])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package workerpool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// Stats is the JSON document served at /stats: the metric counters plus the
+// pool's shape and current queue depth.
+type Stats struct {
+	Workers   int  `json:"workers"`    // requested size: Config.Workers or the last Resize
+	QueueSize int  `json:"queue_size"` // summed over priority levels
+	QueueLen  int  `json:"queue_len"`  // jobs waiting for a worker right now
+	Active    int  `json:"active"`     // workers running a job right now
+	Closed    bool `json:"closed"`
+
+	Submitted      int64 `json:"submitted"`
+	Started        int64 `json:"started"`
+	Succeeded      int64 `json:"succeeded"`
+	Failed         int64 `json:"failed"`
+	Dropped        int64 `json:"dropped"`
+	TimedOut       int64 `json:"timed_out"`
+	Cancelled      int64 `json:"cancelled"`
+	Panicked       int64 `json:"panicked"`
+	Retried        int64 `json:"retried"`
+	Preempted      int64 `json:"preempted"`
+	ResultsDropped int64 `json:"results_dropped"`
+}
+
+// Stats returns a snapshot of the pool's metrics and queue state. Like
+// Metrics, fields are individually but not mutually consistent.
+func (p *Pool) Stats() Stats {
+	m := p.Metrics()
+	return Stats{
+		Workers:        int(atomic.LoadInt32(&p.size)),
+		QueueSize:      p.cfg.QueueSize * len(p.levels),
+		QueueLen:       m.QueueDepth,
+		Active:         m.ActiveWorkers,
+		Closed:         atomic.LoadInt32(&p.closed) == 1,
+		Submitted:      m.Submitted,
+		Started:        m.Started,
+		Succeeded:      m.Succeeded,
+		Failed:         m.Failed,
+		Dropped:        m.Dropped,
+		TimedOut:       m.TimedOut,
+		Cancelled:      m.Cancelled,
+		Panicked:       m.Panicked,
+		Retried:        m.Retried,
+		Preempted:      m.Preempted,
+		ResultsDropped: m.ResultsDropped,
+	}
+}
+
+// WritePrometheus writes s in the Prometheus text exposition format.
+func (s Stats) WritePrometheus(w io.Writer) {
+	metric := func(name, typ, help string, v int64) {
+		fmt.Fprintf(w, "# HELP workerpool_%s %s\n# TYPE workerpool_%s %s\nworkerpool_%s %d\n",
+			name, help, name, typ, name, v)
+	}
+	metric("jobs_submitted_total", "counter", "Jobs accepted into the queue.", s.Submitted)
+	metric("jobs_started_total", "counter", "Jobs picked up by a worker.", s.Started)
+	metric("jobs_succeeded_total", "counter", "Jobs that returned nil.", s.Succeeded)
+	metric("jobs_failed_total", "counter", "Jobs that returned an error or were skipped.", s.Failed)
+	metric("jobs_timed_out_total", "counter", "Failed jobs stopped by their own JobTimeout.", s.TimedOut)
+	metric("jobs_cancelled_total", "counter", "Failed jobs stopped or skipped by a forced shutdown.", s.Cancelled)
+	metric("jobs_panicked_total", "counter", "Failed jobs that panicked.", s.Panicked)
+	metric("jobs_retried_total", "counter", "Reruns of failed jobs.", s.Retried)
+	metric("jobs_preempted_total", "counter", "Preemptible jobs cancelled and re-queued for a more urgent job.", s.Preempted)
+	metric("jobs_dropped_total", "counter", "Jobs rejected or cancelled before being queued.", s.Dropped)
+	metric("results_dropped_total", "counter", "Results discarded because Results was not read.", s.ResultsDropped)
+	metric("workers", "gauge", "Configured number of workers.", int64(s.Workers))
+	metric("queue_capacity", "gauge", "Capacity of the job queue.", int64(s.QueueSize))
+	metric("queue_length", "gauge", "Jobs currently waiting in the queue.", int64(s.QueueLen))
+	metric("active_workers", "gauge", "Workers currently running a job.", int64(s.Active))
+}
+
+// AdminHandler returns an http.Handler for operators, meant to be mounted on
+// an internal port:
+//
+//	GET /metrics  Prometheus text format
+//	GET /stats    Stats as JSON
+//	GET /logs     RecentLogs as plain text, one line each (empty if
+//	              Config.LogBufferSize is 0)
+func (p *Pool) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		p.Stats().WritePrometheus(w)
+	})
+
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p.Stats())
+	})
+
+	mux.HandleFunc("/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if lines := p.RecentLogs(); len(lines) > 0 {
+			io.WriteString(w, strings.Join(lines, "\n")+"\n")
+		}
+	})
+
+	return mux
+}
@@ -0,0 +1,24 @@
+package workerpool
+
+import "context"
+
+// mergedContext is the context handed to a Job. Cancellation (Done, Err,
+// Deadline) comes from the pool's worker context, so a forced shutdown still
+// stops the job; values come from the context the job was submitted with, so
+// request-scoped data such as trace IDs survives the trip through the queue.
+//
+// Value consults the worker context first: it carries no user values, but it
+// answers the context package's internal lookups, which keeps cancellation
+// propagation and context.Cause tied to the worker context for any context
+// derived from this one.
+type mergedContext struct {
+	context.Context                 // worker context: Deadline, Done, Err
+	values          context.Context // submit context: Value only
+}
+
+func (c mergedContext) Value(key any) any {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	return c.values.Value(key)
+}
@@ -0,0 +1,63 @@
+package workerpool
+
+import "sync/atomic"
+
+// ReplaceWith hands the pool over to a new pool built from cfg, for
+// reconfiguring without downtime (queue size, shutdown timeout, ...):
+//
+//  1. The new pool is created and started.
+//  2. From then on Submit, SubmitUnique, SubmitWithResult, SubmitWait,
+//     Prefill and Resize on p forward to it, so callers keep using p.
+//  3. p shuts down in the background: its workers drain the jobs already
+//     queued, under p's ShutdownTimeout. A submit that was already enqueuing
+//     into p when the handoff happened lands in p's queue and is drained
+//     too, or, if it was still waiting for room, is made again on the new
+//     pool, which then owns its ID, result and metrics; every accepted job
+//     runs exactly once.
+//
+// Shutdown on p waits for the drain and then shuts the new pool down.
+// Metrics, Stats, Results, RecentLogs and Workers stay per pool: read them
+// from the returned *Pool for the new one. SubmitUnique deduplicates within
+// one pool, so a key still running in p is not seen by the new pool.
+//
+// The new pool does not reload cfg.Persister: its pending jobs are p's,
+// still queued or running there.
+//
+// Calling ReplaceWith again on p replaces the newest pool. It returns
+// ErrPoolClosed after Shutdown.
+func (p *Pool) ReplaceWith(cfg Config) (*Pool, error) {
+	p.resizeMu.Lock()
+	// Successor first: once replaced, p closes itself while draining.
+	if next := p.successor.Load(); next != nil {
+		p.resizeMu.Unlock()
+		return next.ReplaceWith(cfg)
+	}
+	if atomic.LoadInt32(&p.closed) == 1 {
+		p.resizeMu.Unlock()
+		return nil, ErrPoolClosed
+	}
+	// Not New: the jobs cfg.Persister holds are the ones p is draining.
+	next := newPool(cfg)
+	p.successor.Store(next)
+	p.resizeMu.Unlock()
+
+	p.cfg.Logger.Printf("[pool] replaced — forwarding submits to the new pool, draining %d queued jobs",
+		p.queueLen())
+	go func() {
+		if err := p.shutdownWithin(p.cfg.ShutdownTimeout); err != nil {
+			p.cfg.Logger.Printf("[pool] drain after replace: %v", err)
+		}
+	}()
+	return next, nil
+}
+
+// replacedBy returns p's successor when err is p turning a submit away
+// because ReplaceWith closed it while the submit waited, else nil. The caller
+// then repeats the submit through the successor's own Submit* method, so the
+// job's result, key and metrics belong to the pool that runs it.
+func (p *Pool) replacedBy(err error) *Pool {
+	if err != ErrPoolClosed {
+		return nil
+	}
+	return p.successor.Load()
+}
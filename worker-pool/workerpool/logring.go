@@ -0,0 +1,56 @@
+package workerpool
+
+import (
+	"strings"
+	"sync"
+)
+
+// logRing is an io.Writer that keeps the last len(lines) log lines in memory.
+// log.Logger issues exactly one Write per message, so each Write is stored as
+// one line (trailing newline removed).
+type logRing struct {
+	mu    sync.Mutex
+	lines []string
+	next  int // slot the next line goes into
+	full  bool
+}
+
+func newLogRing(size int) *logRing {
+	return &logRing{lines: make([]string, size)}
+}
+
+func (r *logRing) Write(b []byte) (int, error) {
+	line := strings.TrimSuffix(string(b), "\n")
+
+	r.mu.Lock()
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+
+	return len(b), nil
+}
+
+// snapshot returns the retained lines, oldest first.
+func (r *logRing) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]string(nil), r.lines[:r.next]...)
+	}
+	out := make([]string, 0, len(r.lines))
+	out = append(out, r.lines[r.next:]...)
+	return append(out, r.lines[:r.next]...)
+}
+
+// RecentLogs returns the last Config.LogBufferSize log lines written by the
+// pool, oldest first. It returns nil if LogBufferSize is 0.
+func (p *Pool) RecentLogs() []string {
+	if p.logs == nil {
+		return nil
+	}
+	return p.logs.snapshot()
+}
@@ -0,0 +1,57 @@
+package workerpool
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+// logSampler rate-limits a class of repeated log lines: of every `every`
+// calls to Printf, only the first is written; the rest are counted so a
+// summary can be logged later.
+type logSampler struct {
+	logger *log.Logger
+	every  int64
+	seen   int64 // total Printf calls; accessed atomically
+}
+
+// newLogSampler returns nil when every <= 1: sampling is off.
+func newLogSampler(l *log.Logger, every int) *logSampler {
+	if every <= 1 {
+		return nil
+	}
+	return &logSampler{logger: l, every: int64(every)}
+}
+
+// Printf writes the line if it is the 1st, (N+1)th, (2N+1)th… occurrence.
+func (s *logSampler) Printf(format string, args ...any) {
+	if n := atomic.AddInt64(&s.seen, 1); (n-1)%s.every == 0 {
+		s.logger.Printf(format, args...)
+	}
+}
+
+// suppressed returns how many lines Printf dropped and how many it saw.
+func (s *logSampler) suppressed() (dropped, total int64) {
+	total = atomic.LoadInt64(&s.seen)
+	written := (total + s.every - 1) / s.every // ceil(total / every)
+	return total - written, total
+}
+
+// logFailure logs a job-failure line, sampled by Config.LogSampleEvery.
+func (p *Pool) logFailure(format string, args ...any) {
+	if p.failLog == nil {
+		p.cfg.Logger.Printf(format, args...)
+		return
+	}
+	p.failLog.Printf(format, args...)
+}
+
+// logSuppressed writes the end-of-life summary of sampled-out failure lines.
+func (p *Pool) logSuppressed() {
+	if p.failLog == nil {
+		return
+	}
+	if dropped, total := p.failLog.suppressed(); dropped > 0 {
+		p.cfg.Logger.Printf("[pool] suppressed %d of %d job-failure log lines (LogSampleEvery=%d)",
+			dropped, total, p.cfg.LogSampleEvery)
+	}
+}
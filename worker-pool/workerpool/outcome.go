@@ -0,0 +1,85 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrJobTimeout is the context.Cause of a job context cancelled by
+// Config.JobTimeout. A forced shutdown uses ErrShutdownTimeout instead, so a
+// job can tell "I was too slow" from "the pool is going away":
+//
+//	if errors.Is(context.Cause(ctx), workerpool.ErrJobTimeout) { ... }
+var ErrJobTimeout = errors.New("job exceeded its JobTimeout")
+
+// JobOutcome says how a job that reached a worker ended.
+type JobOutcome int
+
+const (
+	OutcomeSucceeded JobOutcome = iota // returned nil
+	OutcomeFailed                      // returned an error on its own (or injected)
+	OutcomeTimedOut                    // returned an error after its JobTimeout fired
+	OutcomeCancelled                   // stopped or skipped by a forced Shutdown
+)
+
+func (o JobOutcome) String() string {
+	switch o {
+	case OutcomeSucceeded:
+		return "succeeded"
+	case OutcomeFailed:
+		return "failed"
+	case OutcomeTimedOut:
+		return "timed out"
+	case OutcomeCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// JobInfo describes a job that reached a worker, for Config.OnJobDone.
+type JobInfo struct {
+	ID      uint64     // see task.id
+	Outcome JobOutcome // how it ended
+	Err     error      // what the job returned (or why it did not run); nil on success
+
+	// SubmitSite is the "file:line" of the call that submitted the job, or
+	// "" unless Config.CaptureSubmitSite is set.
+	SubmitSite string
+}
+
+// classify maps a job's return value to an outcome. A failure while the job
+// context is done is attributed to whatever cancelled it, read from
+// context.Cause: the job's own timeout or the pool's forced shutdown.
+func classify(ctx context.Context, err error) JobOutcome {
+	switch {
+	case err == nil:
+		return OutcomeSucceeded
+	case ctx.Err() == nil:
+		return OutcomeFailed
+	case errors.Is(context.Cause(ctx), ErrJobTimeout):
+		return OutcomeTimedOut
+	default:
+		return OutcomeCancelled
+	}
+}
+
+// record updates the metrics for a finished job and calls OnJobDone.
+func (p *Pool) record(t task, outcome JobOutcome, err error) {
+	switch outcome {
+	case OutcomeSucceeded:
+		atomic.AddInt64(&p.metrics.Succeeded, 1)
+	case OutcomeTimedOut:
+		atomic.AddInt64(&p.metrics.TimedOut, 1)
+		atomic.AddInt64(&p.metrics.Failed, 1)
+	case OutcomeCancelled:
+		atomic.AddInt64(&p.metrics.Cancelled, 1)
+		atomic.AddInt64(&p.metrics.Failed, 1)
+	default:
+		atomic.AddInt64(&p.metrics.Failed, 1)
+	}
+
+	if p.cfg.OnJobDone != nil {
+		p.cfg.OnJobDone(JobInfo{ID: t.id, Outcome: outcome, Err: err, SubmitSite: t.site})
+	}
+}
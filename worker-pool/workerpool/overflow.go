@@ -0,0 +1,12 @@
+package workerpool
+
+// overflow hands t's job to Config.OverflowSink, if set. Called for jobs the
+// pool accepted responsibility for but could not complete: rejected because
+// the pool was closed, or cancelled (in flight or still queued) by a forced
+// Shutdown.
+func (p *Pool) overflow(t task) {
+	if p.cfg.OverflowSink == nil {
+		return
+	}
+	p.cfg.OverflowSink(t.job)
+}
@@ -0,0 +1,37 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error a job fails with when it panics: the worker
+// recovers, records the job as failed (Metrics.Panicked, also in Failed) and
+// moves on to the next job. SubmitWait and Results receive it like any other
+// job error.
+type PanicError struct {
+	Value any    // what the job passed to panic
+	Stack []byte // the panicking goroutine's stack, from debug.Stack
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Unwrap returns Value if it is an error, so errors.Is sees through a
+// panic(err).
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callJob runs job, turning a panic into a *PanicError.
+func callJob(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return job(ctx)
+}
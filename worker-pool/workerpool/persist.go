@@ -0,0 +1,193 @@
+package workerpool
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Runnable is a job that can outlive the process: a value whose exported
+// fields, encoded as JSON, are all it needs to run. Register each concrete
+// type with RegisterJobType and submit it with SubmitRunnable.
+type Runnable interface {
+	Run(ctx context.Context) error
+}
+
+// PersistedJob is a Runnable as stored by a Persister.
+type PersistedJob struct {
+	ID   string // random, unique across restarts
+	Type string // the name given to RegisterJobType
+	Data []byte // the Runnable encoded as JSON
+}
+
+// Persister stores the jobs submitted with SubmitRunnable until they have
+// run, so that a pool created after a crash or restart can run the ones that
+// had not. Save is called before the job is enqueued, Done once a worker has
+// finished with it, and Load by New for the jobs still pending. Save and
+// Done run on submitting and worker goroutines, so they must be safe for
+// concurrent use.
+type Persister interface {
+	Save(job PersistedJob) error
+	Done(id string) error
+	Load() ([]PersistedJob, error)
+}
+
+// ErrUnregisteredJobType is returned by SubmitRunnable for a Runnable whose
+// type was not passed to RegisterJobType.
+var ErrUnregisteredJobType = fmt.Errorf("runnable type not registered")
+
+// jobTypes maps registered names to decoders and types back to names.
+var jobTypes = struct {
+	sync.RWMutex
+	decode map[string]func([]byte) (Runnable, error)
+	names  map[reflect.Type]string
+}{
+	decode: make(map[string]func([]byte) (Runnable, error)),
+	names:  make(map[reflect.Type]string),
+}
+
+// RegisterJobType makes R submittable with SubmitRunnable and reloadable
+// under name, which is what gets stored: keep it stable across releases, and
+// the same in every process sharing a Persister. Like gob.RegisterName it is
+// meant for init functions, and panics if name or R is already registered.
+func RegisterJobType[R Runnable](name string) {
+	typ := reflect.TypeOf((*R)(nil)).Elem()
+
+	jobTypes.Lock()
+	defer jobTypes.Unlock()
+	if _, dup := jobTypes.decode[name]; dup {
+		panic(fmt.Sprintf("workerpool: job type %q registered twice", name))
+	}
+	if old, dup := jobTypes.names[typ]; dup {
+		panic(fmt.Sprintf("workerpool: %v already registered as %q", typ, old))
+	}
+	jobTypes.names[typ] = name
+	jobTypes.decode[name] = func(data []byte) (Runnable, error) {
+		var r R
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+}
+
+// SubmitRunnable enqueues r like Submit, first saving it to
+// Config.Persister. The saved copy is removed once a worker has finished
+// with r — whatever the outcome, after any retries — or if the submit fails.
+// It is kept when a forced Shutdown cancels or skips r, so the next pool
+// built with the same Persister runs it again: a Runnable must tolerate
+// running twice.
+//
+// r's type must be registered with RegisterJobType, or SubmitRunnable
+// returns ErrUnregisteredJobType. Without a Persister it is Submit(ctx,
+// r.Run).
+func (p *Pool) SubmitRunnable(ctx context.Context, r Runnable) error {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitRunnable(ctx, r)
+	}
+	if p.cfg.Persister == nil {
+		return p.Submit(ctx, r.Run)
+	}
+
+	jobTypes.RLock()
+	name, ok := jobTypes.names[reflect.TypeOf(r)]
+	jobTypes.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnregisteredJobType, r)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+	id, err := newPersistID()
+	if err != nil {
+		return err
+	}
+	if err := p.cfg.Persister.Save(PersistedJob{ID: id, Type: name, Data: data}); err != nil {
+		return fmt.Errorf("persist %s: %w", name, err)
+	}
+
+	if err := p.submit(ctx, p.persistedTask(id, r)); err != nil {
+		p.unpersist(id) // the caller still owns r
+		if next := p.replacedBy(err); next != nil {
+			return next.SubmitRunnable(ctx, r)
+		}
+		return err
+	}
+	return nil
+}
+
+// persistedTask wraps r in a task that removes its saved copy when done,
+// unless a forced shutdown cut it short.
+func (p *Pool) persistedTask(id string, r Runnable) task {
+	return task{
+		id:  p.newID(),
+		job: r.Run,
+		done: func(err error) {
+			if err != nil && p.workerCtx.Err() != nil {
+				return // cancelled or skipped by a forced shutdown: keep it
+			}
+			p.unpersist(id)
+		},
+	}
+}
+
+func (p *Pool) unpersist(id string) {
+	if err := p.cfg.Persister.Done(id); err != nil {
+		p.cfg.Logger.Printf("[pool] persister: marking job %s done: %v", id, err)
+	}
+}
+
+// reload enqueues the jobs Config.Persister still holds from a previous
+// pool. It runs in the background so New does not block on a small queue;
+// jobs it cannot decode or enqueue (the pool was shut down first) stay
+// saved for a later run.
+func (p *Pool) reload() {
+	saved, err := p.cfg.Persister.Load()
+	if err != nil {
+		p.cfg.Logger.Printf("[pool] persister: loading pending jobs: %v", err)
+		return
+	}
+	if len(saved) == 0 {
+		return
+	}
+	p.cfg.Logger.Printf("[pool] reloading %d persisted jobs", len(saved))
+
+	tasks := make([]task, 0, len(saved))
+	for _, pj := range saved {
+		jobTypes.RLock()
+		decode, ok := jobTypes.decode[pj.Type]
+		jobTypes.RUnlock()
+		if !ok {
+			p.cfg.Logger.Printf("[pool] persister: job %s: %v: %q", pj.ID, ErrUnregisteredJobType, pj.Type)
+			continue
+		}
+		r, err := decode(pj.Data)
+		if err != nil {
+			p.cfg.Logger.Printf("[pool] persister: job %s: decode %s: %v", pj.ID, pj.Type, err)
+			continue
+		}
+		tasks = append(tasks, p.persistedTask(pj.ID, r))
+	}
+
+	go func() {
+		for _, t := range tasks {
+			if err := p.submit(context.Background(), t); err != nil {
+				return // closed: the rest stay saved
+			}
+		}
+	}()
+}
+
+// newPersistID returns 16 random bytes in hex.
+func newPersistID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("persisted job id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
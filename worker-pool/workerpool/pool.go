@@ -5,17 +5,50 @@ package workerpool
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-// Job is the unit of work submitted to the pool. The function receives the
-// pool's context so it can respect cancellation.
+// Job is the unit of work submitted to the pool. The function receives a
+// context that is cancelled on forced shutdown and carries the values of the
+// context passed to Submit (see mergedContext).
 type Job func(ctx context.Context) error
 
+// task is what travels through the jobs channel: the job plus the context it
+// was submitted with, kept only for its values.
+type task struct {
+	id        uint64 // unique per pool, in submission order starting at 1
+	job       Job
+	submitCtx context.Context
+
+	// done, if set, is called once the worker is finished with the task:
+	// with the job's final error (after any retries), or with the reason it
+	// did not run (forced shutdown or an injected failure). It is where
+	// SubmitUnique, SubmitWithResult and SubmitWait do their bookkeeping
+	// (releasing a key, publishing a result, waking the caller).
+	done func(err error)
+
+	// site is the "file:line" of the submit call with CaptureSubmitSite.
+	site string
+
+	// level is the priority level whose queue the task was sent on; a retry
+	// goes back there. attempt counts the runs that already failed, so the
+	// next run is attempt number attempt+1.
+	level   int
+	attempt int
+
+	// preemptible marks a SubmitPreemptible job; preemptions counts the
+	// times it was preempted and re-queued.
+	preemptible bool
+	preemptions int
+}
+
 // Config holds pool construction parameters.
 type Config struct {
 	// Workers is the number of goroutines that consume jobs concurrently.
@@ -23,14 +56,138 @@ type Config struct {
 
 	// QueueSize is the capacity of the internal job channel. A value of 0
 	// makes the channel unbuffered (submit blocks until a worker is free).
+	// With PriorityLevels each level has its own queue of this size.
 	QueueSize int
 
+	// PriorityLevels, if > 1, gives the pool that many queues, 0 the most
+	// urgent: SubmitPriority picks the level, Submit and the other Submit*
+	// methods use the least urgent one, and idle workers take the most
+	// urgent job waiting. Defaults to 1, a single FIFO queue.
+	PriorityLevels int
+
+	// PriorityStarvationLimit bounds how many jobs a worker takes from more
+	// urgent levels while a less urgent one has work waiting, before it
+	// serves that level once. Defaults to 8.
+	PriorityStarvationLimit int
+
 	// ShutdownTimeout is the maximum time Shutdown waits for in-flight jobs
 	// to finish before forcefully cancelling them. Defaults to 30 s.
+	// ShutdownContext takes its deadline from its ctx instead.
 	ShutdownTimeout time.Duration
 
 	// Logger is used for structured output. If nil, log.Default() is used.
 	Logger *log.Logger
+
+	// LazyStart defers starting the workers until the first Submit, so a
+	// pool that never receives jobs costs no goroutines. Prefill does not
+	// start them: jobs enqueued that way wait for the first Submit (or are
+	// drained by Shutdown).
+	LazyStart bool
+
+	// ResultBuffer is the capacity of the Results channel. Defaults to
+	// QueueSize + Workers, enough for every queued and running job.
+	ResultBuffer int
+
+	// LogBufferSize, if > 0, makes the pool also keep its last N log lines
+	// in memory for RecentLogs. Lines still go to Logger; pass a Logger
+	// writing to io.Discard to keep them only in memory.
+	LogBufferSize int
+
+	// OnShutdown, if set, is called exactly once at the end of the first
+	// Shutdown — clean or forced — after every worker has exited, with the
+	// final metrics. Shutdown returns only after it does, so it is the place
+	// to flush logs or emit a final report.
+	OnShutdown func(Metrics)
+
+	// FailureInjector, if set, is called with each job's ID right before a
+	// worker would run it. A non-nil error fails the job with that error
+	// without running it — for chaos tests of error handling and metrics.
+	// IDs are assigned in submission order starting at 1 (see task.id).
+	FailureInjector func(jobID uint64) error
+
+	// LogSampleEvery, if > 1, logs only the first of every N job-failure
+	// lines, so a burst of failing jobs cannot flood the log. Shutdown logs
+	// how many lines were suppressed. Metrics still count every failure.
+	LogSampleEvery int
+
+	// SubmitRate, if set, bounds how fast jobs enter the pool: Submit (and
+	// SubmitUnique, SubmitWithResult, SubmitWait) first waits for a token,
+	// honouring the caller's ctx and Shutdown, then enqueues. This shapes ingress
+	// independently of QueueSize. Prefill does not wait and bypasses it.
+	SubmitRate *RateLimiter
+
+	// JobTimeout, if > 0, bounds each job: its context is cancelled after
+	// JobTimeout with cause ErrJobTimeout. A job that returns an error after
+	// that counts as TimedOut, not as Cancelled by Shutdown.
+	JobTimeout time.Duration
+
+	// MaxRetries, if > 0, reruns a job that failed (returned an error or
+	// timed out) up to that many more times: after RetryBackoff(attempt) the
+	// job goes back to the end of its queue, and the worker takes other jobs
+	// meanwhile. Panics, injected failures and shutdown cancellations are
+	// not retried. Metrics, OnJobDone and the Submit* bookkeeping see only
+	// the final attempt; Metrics.Retried counts the reruns.
+	MaxRetries int
+
+	// RetryBackoff returns how long to wait after failed attempt number
+	// attempt (1-based). Defaults to ExponentialBackoff(100ms, 10s, nil).
+	RetryBackoff func(attempt int) time.Duration
+
+	// OnJobDone, if set, is called on the worker goroutine after every job
+	// that reached a worker, with its ID, how it ended, the error it returned
+	// (nil on success) and, with CaptureSubmitSite, where it was submitted.
+	// It must be quick and safe for concurrent use.
+	OnJobDone func(JobInfo)
+
+	// OnJobStart and OnJobComplete, if set, are called on the worker
+	// goroutine immediately before and after each run of a job, with the
+	// context the job runs with (span and submit-time values included).
+	// OnJobComplete also gets the job's error and how long it ran. Unlike
+	// OnJobDone they fire once per attempt when MaxRetries reruns a job,
+	// and not for jobs skipped before running. Both must be quick and safe
+	// for concurrent use.
+	OnJobStart    func(ctx context.Context)
+	OnJobComplete func(ctx context.Context, err error, dur time.Duration)
+
+	// CaptureSubmitSite records the file:line of the call that submitted
+	// each job (via runtime.Callers) and adds it to failure log lines and
+	// JobInfo.SubmitSite, to trace which code path submitted a failing job.
+	// Off by default, and then free: walking the stack on every submit is
+	// not.
+	CaptureSubmitSite bool
+
+	// Tracer, if set, wraps every job a worker runs in a span named
+	// JobSpanName, started with the job's context (so submit-time values
+	// such as a parent span are visible) and ended with the job's error.
+	// Defaults to a no-op tracer.
+	Tracer Tracer
+
+	// LockOSThread makes every worker call runtime.LockOSThread when it
+	// starts and UnlockOSThread when it exits, so all of a worker's jobs run
+	// on one OS thread. Useful for cgo libraries or syscalls that keep
+	// per-thread state; it costs one OS thread per worker and slows the
+	// scheduler, so leave it off for plain Go work.
+	LockOSThread bool
+
+	// OverflowSink, if set, receives every job the pool accepted but could
+	// not complete, so the caller can persist it for later reprocessing:
+	//
+	//   - jobs rejected with ErrPoolClosed by Submit, SubmitUnique,
+	//     SubmitWithResult, SubmitWait or Prefill;
+	//   - jobs cancelled by a forced Shutdown, whether in flight
+	//     (OutcomeCancelled) or still queued and skipped.
+	//
+	// Jobs that time out, fail or are failed by FailureInjector are not
+	// overflow. SubmitWithResult jobs arrive adapted to Job, their value
+	// discarded. OverflowSink runs on the submitting or worker goroutine, so
+	// it must be quick and safe for concurrent use.
+	OverflowSink func(Job)
+
+	// Persister, if set, saves every job submitted with SubmitRunnable
+	// until a worker has finished with it, and New reloads the jobs it still
+	// holds from a previous run. Jobs from Submit and the other Submit*
+	// methods are plain funcs and are never persisted.
+	Persister Persister
 }
 
 func (c *Config) withDefaults() Config {
@@ -44,17 +201,58 @@ func (c *Config) withDefaults() Config {
 	if out.Logger == nil {
 		out.Logger = log.Default()
 	}
+	if out.ResultBuffer <= 0 {
+		out.ResultBuffer = out.QueueSize + out.Workers
+	}
+	if out.RetryBackoff == nil {
+		out.RetryBackoff = ExponentialBackoff(100*time.Millisecond, 10*time.Second, nil)
+	}
+	if out.PriorityLevels <= 0 {
+		out.PriorityLevels = 1
+	}
+	if out.PriorityStarvationLimit <= 0 {
+		out.PriorityStarvationLimit = defaultStarvationLimit
+	}
+	if out.Tracer == nil {
+		out.Tracer = noopTracer{}
+	}
 	return out
 }
 
-// Metrics exposes live pool counters. All fields are updated atomically and
-// safe to read from any goroutine.
+// Metrics exposes live pool counters and gauges. All fields are updated
+// atomically and safe to read from any goroutine.
 type Metrics struct {
 	Submitted int64 // total jobs ever enqueued
 	Started   int64 // jobs a worker picked up
 	Succeeded int64 // jobs that returned nil
 	Failed    int64 // jobs that returned a non-nil error
 	Dropped   int64 // jobs rejected after shutdown began
+
+	// TimedOut and Cancelled break down Failed: jobs stopped by their own
+	// Config.JobTimeout, and jobs stopped (or skipped) because Shutdown
+	// force-cancelled the workers. Both are also counted in Failed.
+	TimedOut  int64
+	Cancelled int64
+
+	// Panicked counts jobs that panicked (see PanicError); also in Failed.
+	Panicked int64
+
+	// Retried counts reruns of failed jobs (see Config.MaxRetries). A job is
+	// counted once in Succeeded or Failed however many times it ran.
+	Retried int64
+
+	// Preempted counts runs of preemptible jobs cancelled and re-queued for
+	// a more urgent job (see SubmitPreemptible); not failures.
+	Preempted int64
+
+	ResultsDropped int64 // results discarded because Results was not read
+
+	// Gauges, sampled when Metrics is called: jobs waiting in the queue (all
+	// priority levels) and jobs a worker is running right now. ActiveWorkers
+	// at the worker count with a growing QueueDepth means the pool is
+	// saturated.
+	QueueDepth    int
+	ActiveWorkers int
 }
 
 // Pool is a fixed-size worker pool.
@@ -66,63 +264,247 @@ type Metrics struct {
 //	pool.Shutdown()       // stop accepting, drain, cancel stragglers
 type Pool struct {
 	cfg     Config
-	jobs    chan Job
+	jobs    chan task      // the least urgent level, used by Submit
+	levels  []chan task    // one queue per priority level, most urgent first
 	wg      sync.WaitGroup // tracks live worker goroutines
 	metrics Metrics
 
-	// cancelWorkers stops workers when ShutdownTimeout elapses.
-	cancelWorkers context.CancelFunc
+	// cancelWorkers stops workers when the shutdown deadline passes, with cause
+	// ErrShutdownTimeout (see context.Cause).
+	cancelWorkers context.CancelCauseFunc
 	workerCtx     context.Context
 
 	// once ensures Shutdown is idempotent.
 	once sync.Once
 
+	// startOnce guards startWorkers; with LazyStart it first fires in Submit.
+	startOnce sync.Once
+
 	// closed is set to 1 atomically when Shutdown begins; Submit reads it.
 	closed int32
+
+	// submitMu is held for reading by submitters from the closed check
+	// through the send, and for writing by Shutdown while it sets closed, so
+	// jobs is never closed under a pending send.
+	submitMu sync.RWMutex
+
+	// closing is closed when Shutdown begins, before it takes submitMu.
+	// Submitters blocked under submitMu's read lock — on a full queue or a
+	// SubmitRate token — select on it and give up with ErrPoolClosed, so
+	// Shutdown never waits on a submitter that waits on a worker.
+	closing chan struct{}
+
+	// successor is the pool that took over after ReplaceWith; nil until then.
+	successor atomic.Pointer[Pool]
+
+	// sched owns the goroutines of Every and At; Shutdown stops them first.
+	sched scheduler
+
+	// activeKeys holds the keys of SubmitUnique jobs that are queued or
+	// running; guarded by keysMu.
+	keysMu     sync.Mutex
+	activeKeys map[string]struct{}
+
+	// results carries SubmitWithResult outcomes; closed by Shutdown once all
+	// workers have exited.
+	results chan JobResult
+
+	// nextID is the last job ID handed out (see task.id).
+	nextID uint64
+
+	// logs retains recent log lines when LogBufferSize > 0; nil otherwise.
+	logs *logRing
+
+	// failLog samples job-failure lines when LogSampleEvery > 1; nil otherwise.
+	failLog *logSampler
+
+	// resizeMu guards stops and nextWorker, and orders Resize against
+	// Shutdown so no worker is added once the pool is closed. stops holds one
+	// channel per running worker; closing it asks that worker to exit.
+	resizeMu   sync.Mutex
+	stops      []chan struct{}
+	nextWorker int
+
+	// size is the requested worker count (Config.Workers, then the last
+	// Resize); live is the number of worker goroutines still running.
+	// Both are accessed atomically.
+	size int32
+	live int32
+
+	// active is the number of workers running a job; accessed atomically.
+	active int32
+
+	// running holds the preemptible jobs in flight, by task ID, so
+	// preemptFor can cancel one; guarded by runningMu.
+	runningMu sync.Mutex
+	running   map[uint64]*runningJob
 }
 
-// New creates a Pool and starts N worker goroutines. Workers run until
-// Shutdown is called.
+// New creates a Pool and starts N worker goroutines, or defers that to the
+// first Submit if cfg.LazyStart is set. Workers run until Shutdown is called.
+// With cfg.Persister, New also enqueues the jobs it still holds.
 func New(cfg Config) *Pool {
+	p := newPool(cfg)
+	if p.cfg.Persister != nil {
+		p.reload()
+	}
+	return p
+}
+
+// newPool is New without reloading persisted jobs.
+func newPool(cfg Config) *Pool {
 	cfg = cfg.withDefaults()
 
-	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	workerCtx, cancelWorkers := context.WithCancelCause(context.Background())
 
 	p := &Pool{
 		cfg:           cfg,
-		jobs:          make(chan Job, cfg.QueueSize),
+		levels:        make([]chan task, cfg.PriorityLevels),
 		workerCtx:     workerCtx,
 		cancelWorkers: cancelWorkers,
+		activeKeys:    make(map[string]struct{}),
+		results:       make(chan JobResult, cfg.ResultBuffer),
+		closing:       make(chan struct{}),
+		running:       make(map[uint64]*runningJob),
+		size:          int32(cfg.Workers),
 	}
 
-	p.cfg.Logger.Printf("[pool] starting %d workers (queue=%d, shutdownTimeout=%s)",
-		cfg.Workers, cfg.QueueSize, cfg.ShutdownTimeout)
+	for i := range p.levels {
+		p.levels[i] = make(chan task, cfg.QueueSize)
+	}
+	p.jobs = p.levels[len(p.levels)-1]
+
+	p.sched.ctx, p.sched.stop = context.WithCancel(context.Background())
+
+	if cfg.LogBufferSize > 0 {
+		// Tee every line into the ring, keeping the caller's format.
+		p.logs = newLogRing(cfg.LogBufferSize)
+		l := cfg.Logger
+		p.cfg.Logger = log.New(io.MultiWriter(l.Writer(), p.logs), l.Prefix(), l.Flags())
+	}
+
+	// Built after the tee above so sampled lines also reach the ring.
+	p.failLog = newLogSampler(p.cfg.Logger, cfg.LogSampleEvery)
 
-	for i := 0; i < cfg.Workers; i++ {
-		p.wg.Add(1)
-		go p.runWorker(i)
+	if cfg.LazyStart {
+		p.cfg.Logger.Printf("[pool] lazy start: %d workers deferred to first submit", cfg.Workers)
+	} else {
+		p.startOnce.Do(p.startWorkers)
 	}
 
 	return p
 }
 
+// startWorkers launches the worker goroutines. Always call it through
+// startOnce.
+func (p *Pool) startWorkers() {
+	p.cfg.Logger.Printf("[pool] starting %d workers (queue=%d, shutdownTimeout=%s)",
+		p.cfg.Workers, p.cfg.QueueSize, p.cfg.ShutdownTimeout)
+
+	p.resizeMu.Lock()
+	defer p.resizeMu.Unlock()
+	for i := 0; i < p.cfg.Workers; i++ {
+		p.startWorkerLocked()
+	}
+}
+
+// startWorkerLocked launches one worker goroutine. p.resizeMu must be held.
+func (p *Pool) startWorkerLocked() {
+	stop := make(chan struct{})
+	p.stops = append(p.stops, stop)
+	id := p.nextWorker
+	p.nextWorker++
+
+	p.wg.Add(1)
+	atomic.AddInt32(&p.live, 1)
+	go p.runWorker(id, stop)
+}
+
 // Submit enqueues a job. It returns ErrPoolClosed if the pool is shutting down,
 // or ErrQueueFull if the internal channel is full (only possible with a buffered
 // queue and a non-blocking send path — here we block on send).
 //
 // Submit blocks if the queue is full, respecting the caller's context so
 // the caller can time-out or cancel the submission itself.
+//
+// Values stored in ctx (trace IDs, auth) are visible to the job through its
+// own context, but cancelling ctx after Submit returns does not affect the job.
 func (p *Pool) Submit(ctx context.Context, job Job) error {
+	if next := p.successor.Load(); next != nil {
+		return next.Submit(ctx, job)
+	}
+	err := p.submit(ctx, task{id: p.newID(), job: job})
+	if next := p.replacedBy(err); next != nil {
+		return next.Submit(ctx, job)
+	}
+	return err
+}
+
+// newID returns the next job ID.
+func (p *Pool) newID() uint64 {
+	return atomic.AddUint64(&p.nextID, 1)
+}
+
+// submit is Submit for a prepared task; t.submitCtx is set to ctx.
+func (p *Pool) submit(ctx context.Context, t task) error {
+	return p.submitTo(ctx, len(p.levels)-1, t)
+}
+
+// submitTo is submit into the queue of one priority level. A job p turns
+// away because it is closed goes to Config.OverflowSink, unless ReplaceWith
+// closed p: then the public Submit* method resubmits it to the successor (see
+// replacedBy), so its bookkeeping belongs to the pool that runs it.
+func (p *Pool) submitTo(ctx context.Context, level int, t task) error {
+	err := p.enqueue(ctx, level, t)
+	if err == nil && level < len(p.levels)-1 {
+		p.preemptFor(level)
+	}
+	if err == ErrPoolClosed && p.successor.Load() == nil {
+		p.overflow(t)
+	}
+	return err
+}
+
+// enqueue sends t on the queue of level under submitMu. It returns ErrPoolClosed as is
+// once Shutdown has begun, including while it waits for a SubmitRate token
+// or for queue space, so Shutdown never waits on a blocked submitter.
+func (p *Pool) enqueue(ctx context.Context, level int, t task) error {
+	// Start before checking closed: if Shutdown has already claimed startOnce
+	// this is a no-op and the closed check below is guaranteed to see 1.
+	p.startOnce.Do(p.startWorkers)
+
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+
 	if atomic.LoadInt32(&p.closed) == 1 {
-		atomic.AddInt64(&p.metrics.Dropped, 1)
+		if p.successor.Load() == nil {
+			atomic.AddInt64(&p.metrics.Dropped, 1)
+		}
 		return ErrPoolClosed
 	}
 
 	atomic.AddInt64(&p.metrics.Submitted, 1)
 
+	if p.cfg.SubmitRate != nil {
+		if err := p.cfg.SubmitRate.wait(ctx, p.closing); err != nil {
+			if err == ErrPoolClosed {
+				return p.turnedAway() // Shutdown began while waiting for a token
+			}
+			atomic.AddInt64(&p.metrics.Dropped, 1)
+			// Caller cancelled while waiting for a rate-limit token.
+			return fmt.Errorf("submit cancelled: %w", err)
+		}
+	}
+
+	t.submitCtx = ctx
+	t.site = p.submitSite(ctx)
+	t.level = level
 	select {
-	case p.jobs <- job:
+	case p.levels[level] <- t:
 		return nil
+	case <-p.closing:
+		// Shutdown began while waiting for queue space.
+		return p.turnedAway()
 	case <-ctx.Done():
 		// Caller cancelled while waiting for queue space.
 		atomic.AddInt64(&p.metrics.Dropped, 1)
@@ -130,54 +512,152 @@ func (p *Pool) Submit(ctx context.Context, job Job) error {
 	}
 }
 
-// Shutdown stops the pool gracefully:
-//  1. Marks the pool as closed so no new jobs are accepted.
-//  2. Closes the jobs channel so workers drain the remaining queue and exit.
-//  3. Waits up to ShutdownTimeout for workers to finish.
-//  4. If the timeout elapses, cancels all worker contexts and waits for
+// turnedAway accounts for a counted submit that enqueue gives up on because
+// Shutdown began, and returns ErrPoolClosed. The job is Dropped, unless
+// ReplaceWith closed p: then it is resubmitted to the successor, which counts
+// it, so p uncounts it.
+func (p *Pool) turnedAway() error {
+	if p.successor.Load() != nil {
+		atomic.AddInt64(&p.metrics.Submitted, -1)
+	} else {
+		atomic.AddInt64(&p.metrics.Dropped, 1)
+	}
+	return ErrPoolClosed
+}
+
+// Shutdown stops the pool gracefully, allowing ShutdownTimeout for the
+// drain. It is ShutdownContext with a context that expires after
+// Config.ShutdownTimeout; after ReplaceWith, each pool in the chain gets its
+// own ShutdownTimeout.
+func (p *Pool) Shutdown() error {
+	p.stopSchedules()
+	err := p.shutdownWithin(p.cfg.ShutdownTimeout)
+	if next := p.successor.Load(); next != nil {
+		return next.Shutdown()
+	}
+	return err
+}
+
+// ShutdownContext stops the pool gracefully, draining until ctx is done:
+//  1. Stops the schedules of Every and At, then marks the pool as closed so
+//     no new jobs are accepted.
+//  2. Closes the job queues so workers drain the remaining jobs and exit.
+//  3. Waits for workers to finish, or for ctx to be done.
+//  4. If ctx is done first, cancels all worker contexts and waits for
 //     workers to exit (they must respect ctx cancellation).
+//  5. Closes the Results channel once no worker can publish to it.
+//  6. Logs how many failure lines LogSampleEvery suppressed, then calls
+//     Config.OnShutdown with the final metrics, if set.
 //
-// Shutdown is safe to call more than once; subsequent calls are no-ops.
-// It returns ErrShutdownTimeout if a forced cancellation was required.
-func (p *Pool) Shutdown() error {
+// An already-done ctx skips the drain and cancels in-flight jobs at once.
+// ShutdownContext and Shutdown are safe to call more than once; subsequent
+// calls are no-ops. It returns ErrShutdownTimeout if a forced cancellation
+// was required.
+//
+// After ReplaceWith, ShutdownContext waits for this pool's drain and then
+// shuts down the replacement too under the same ctx, returning its error.
+func (p *Pool) ShutdownContext(ctx context.Context) error {
+	p.stopSchedules()
+	err := p.shutdown(ctx)
+	if next := p.successor.Load(); next != nil {
+		return next.ShutdownContext(ctx)
+	}
+	return err
+}
+
+// shutdownWithin is shutdown with a deadline d from now.
+func (p *Pool) shutdownWithin(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return p.shutdown(ctx)
+}
+
+// shutdown is ShutdownContext for this pool alone, ignoring any successor.
+func (p *Pool) shutdown(ctx context.Context) error {
 	var shutdownErr error
 
 	p.once.Do(func() {
 		p.cfg.Logger.Printf("[pool] shutdown initiated")
 
-		// 1. Stop accepting new jobs.
+		// 1. Stop accepting new jobs. Under resizeMu, so a concurrent Resize
+		//    either finishes first or sees closed and adds no worker; and
+		//    under submitMu, so submits already past the closed check finish
+		//    their send before jobs is closed below. Closing closing first
+		//    wakes submits blocked on a full queue; if one is stuck anyway
+		//    (say, in OverflowSink) when ctx is done, cancel the workers
+		//    rather than wait past the deadline for the lock.
+		close(p.closing)
+		locked := make(chan struct{})
+		go func() {
+			p.submitMu.Lock()
+			close(locked)
+		}()
+		select {
+		case <-locked:
+		case <-ctx.Done():
+			p.cfg.Logger.Printf("[pool] shutdown deadline reached (%v) waiting for submitters — cancelling workers",
+				context.Cause(ctx))
+			p.cancelWorkers(ErrShutdownTimeout)
+			shutdownErr = ErrShutdownTimeout
+			<-locked
+		}
+		p.resizeMu.Lock()
 		atomic.StoreInt32(&p.closed, 1)
+		p.resizeMu.Unlock()
+		p.submitMu.Unlock()
+
+		// A lazy pool that was never submitted to has no workers. Claim
+		// startOnce so none start from now on, unless Prefill left jobs in
+		// the queue that still need draining.
+		p.startOnce.Do(func() {
+			if p.queueLen() > 0 {
+				p.startWorkers()
+			}
+		})
 
 		// 2. Signal workers: no more jobs will arrive.
-		close(p.jobs)
+		p.closeQueues()
 
-		// 3. Wait up to ShutdownTimeout for a clean drain.
+		// 3. Wait until ctx is done for a clean drain.
 		done := make(chan struct{})
 		go func() {
 			p.wg.Wait()
+			close(p.results) // 5. no worker left to publish
 			close(done)
 		}()
 
 		select {
 		case <-done:
-			p.cfg.Logger.Printf("[pool] shutdown complete (all workers exited cleanly)")
-
-		case <-time.After(p.cfg.ShutdownTimeout):
-			// 4. Timeout: force-cancel in-flight jobs.
-			p.cfg.Logger.Printf("[pool] shutdown timeout (%s) elapsed — cancelling workers",
-				p.cfg.ShutdownTimeout)
-			p.cancelWorkers()
+		case <-ctx.Done():
+			// 4. Deadline: force-cancel in-flight jobs, unless step 1
+			//    already had to.
+			if shutdownErr == nil {
+				p.cfg.Logger.Printf("[pool] shutdown deadline reached (%v) — cancelling workers",
+					context.Cause(ctx))
+				p.cancelWorkers(ErrShutdownTimeout)
+				shutdownErr = ErrShutdownTimeout
+			}
 			<-done // wait for workers to ack cancellation
+		}
+		if shutdownErr != nil {
 			p.cfg.Logger.Printf("[pool] shutdown complete (forced)")
-			shutdownErr = ErrShutdownTimeout
+		} else {
+			p.cfg.Logger.Printf("[pool] shutdown complete (all workers exited cleanly)")
+		}
+
+		// 6. Both branches above waited for done, so the counters are final.
+		p.logSuppressed()
+		if p.cfg.OnShutdown != nil {
+			p.cfg.OnShutdown(p.Metrics())
 		}
 	})
 
 	return shutdownErr
 }
 
-// Metrics returns a snapshot of pool counters. Values are consistent within
-// each field but may not be mutually consistent across fields (no global lock).
+// Metrics returns a snapshot of pool counters and gauges. Values are
+// consistent within each field but may not be mutually consistent across
+// fields (no global lock).
 func (p *Pool) Metrics() Metrics {
 	return Metrics{
 		Submitted: atomic.LoadInt64(&p.metrics.Submitted),
@@ -185,37 +665,159 @@ func (p *Pool) Metrics() Metrics {
 		Succeeded: atomic.LoadInt64(&p.metrics.Succeeded),
 		Failed:    atomic.LoadInt64(&p.metrics.Failed),
 		Dropped:   atomic.LoadInt64(&p.metrics.Dropped),
+		TimedOut:  atomic.LoadInt64(&p.metrics.TimedOut),
+		Cancelled: atomic.LoadInt64(&p.metrics.Cancelled),
+		Panicked:  atomic.LoadInt64(&p.metrics.Panicked),
+		Retried:   atomic.LoadInt64(&p.metrics.Retried),
+		Preempted: atomic.LoadInt64(&p.metrics.Preempted),
+
+		ResultsDropped: atomic.LoadInt64(&p.metrics.ResultsDropped),
+
+		QueueDepth:    p.queueLen(),
+		ActiveWorkers: int(atomic.LoadInt32(&p.active)),
 	}
 }
 
 // runWorker is the goroutine body for one worker.
-func (p *Pool) runWorker(id int) {
+func (p *Pool) runWorker(id int, stop <-chan struct{}) {
 	defer p.wg.Done()
+	defer atomic.AddInt32(&p.live, -1)
+	if p.cfg.LockOSThread {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
 	p.cfg.Logger.Printf("[worker %d] started", id)
 
-	for job := range p.jobs {
-		// Check whether a force-cancel happened before we even start.
-		if p.workerCtx.Err() != nil {
-			p.cfg.Logger.Printf("[worker %d] skipping job: context already cancelled", id)
-			atomic.AddInt64(&p.metrics.Failed, 1)
-			continue
+	passed := make([]int, len(p.levels)) // see next
+	for {
+		t, ok := p.next(id, stop, passed)
+		if !ok {
+			return
 		}
+		p.runTask(id, t)
+	}
+}
+
+// runTask runs one attempt of a dequeued task on worker id. If the attempt
+// fails and may be retried, the task is scheduled to go back on its queue;
+// otherwise its outcome is recorded.
+func (p *Pool) runTask(id int, t task) {
+	// Check whether a force-cancel happened before we even start.
+	if err := p.workerCtx.Err(); err != nil {
+		p.logFailure("[worker %d] skipping job: context already cancelled%s", id, t.siteSuffix())
+		p.record(t, OutcomeCancelled, err)
+		t.finish(err)
+		p.overflow(t)
+		return
+	}
 
+	first := t.attempt == 0 && t.preemptions == 0
+	switch {
+	case first:
 		atomic.AddInt64(&p.metrics.Started, 1)
+	case t.attempt > 0:
+		atomic.AddInt64(&p.metrics.Retried, 1)
+	}
+	atomic.AddInt32(&p.active, 1)
+	defer atomic.AddInt32(&p.active, -1)
+
+	if inject := p.cfg.FailureInjector; inject != nil && first {
+		if err := inject(t.id); err != nil {
+			p.logFailure("[worker %d] job %d failed (injected): %v%s", id, t.id, err, t.siteSuffix())
+			p.record(t, OutcomeFailed, err)
+			t.finish(err)
+			return
+		}
+	}
 
-		if err := job(p.workerCtx); err != nil {
-			atomic.AddInt64(&p.metrics.Failed, 1)
-			p.cfg.Logger.Printf("[worker %d] job failed: %v", id, err)
-		} else {
-			atomic.AddInt64(&p.metrics.Succeeded, 1)
+	outcome, err, preempted := p.runAttempt(t)
+	if preempted && p.workerCtx.Err() == nil {
+		atomic.AddInt64(&p.metrics.Preempted, 1)
+		p.cfg.Logger.Printf("[worker %d] job %d preempted by a more urgent job; re-queued", id, t.id)
+		t.preemptions++
+		p.requeueAfter(id, t, 0, err)
+		return
+	}
+	var perr *PanicError
+	if errors.As(err, &perr) {
+		// A panic is a bug in the job, whatever the state of its context.
+		outcome = OutcomeFailed
+		atomic.AddInt64(&p.metrics.Panicked, 1)
+	}
+	if attempt := t.attempt + 1; p.shouldRetry(outcome, perr, attempt) {
+		backoff := p.cfg.RetryBackoff(attempt)
+		p.logFailure("[worker %d] job %d attempt %d failed: %v; retrying in %s%s",
+			id, t.id, attempt, err, backoff, t.siteSuffix())
+		t.attempt++
+		p.requeueAfter(id, t, backoff, err)
+		return
+	}
+	p.conclude(id, t, outcome, err, perr)
+}
+
+// conclude logs how t ended on worker id, records it and runs its done hook.
+func (p *Pool) conclude(id int, t task, outcome JobOutcome, err error, perr *PanicError) {
+	switch outcome {
+	case OutcomeTimedOut:
+		p.logFailure("[worker %d] job %d timed out after %s: %v%s", id, t.id, p.cfg.JobTimeout, err, t.siteSuffix())
+	case OutcomeCancelled:
+		p.logFailure("[worker %d] job %d cancelled by shutdown: %v%s", id, t.id, err, t.siteSuffix())
+		p.overflow(t)
+	case OutcomeFailed:
+		if perr != nil {
+			p.logFailure("[worker %d] job %d panicked: %v%s\n%s", id, t.id, perr.Value, t.siteSuffix(), perr.Stack)
+			break
 		}
+		p.logFailure("[worker %d] job failed: %v%s", id, err, t.siteSuffix())
 	}
+	p.record(t, outcome, err)
+	t.finish(err)
+}
 
-	p.cfg.Logger.Printf("[worker %d] exited", id)
+// runAttempt runs t's job once, under its own JobTimeout and span, and
+// classifies how it ended. preempted reports that a preemptible job was
+// cancelled by preemptFor and returned an error.
+func (p *Pool) runAttempt(t task) (outcome JobOutcome, err error, preempted bool) {
+	var ctx context.Context = mergedContext{Context: p.workerCtx, values: t.submitCtx}
+	if p.cfg.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, p.cfg.JobTimeout, ErrJobTimeout)
+		defer cancel()
+	}
+	if t.preemptible {
+		var cancel context.CancelCauseFunc
+		ctx, cancel = context.WithCancelCause(ctx)
+		defer cancel(nil)
+		p.track(t, cancel)
+		defer func() { preempted = p.untrack(t) && err != nil }()
+	}
+	if p.cfg.MaxRetries > 0 {
+		ctx = context.WithValue(ctx, attemptKey{}, attemptInfo{p: p, attempt: t.attempt})
+	}
+
+	ctx, finish := p.cfg.Tracer.StartSpan(ctx, JobSpanName)
+	if p.cfg.OnJobStart != nil {
+		p.cfg.OnJobStart(ctx)
+	}
+	start := time.Now()
+	err = callJob(ctx, t.job)
+	if p.cfg.OnJobComplete != nil {
+		p.cfg.OnJobComplete(ctx, err, time.Since(start))
+	}
+	finish(err)
+	return classify(ctx, err), err, false
+}
+
+// finish reports to t.done, if set, that the worker is finished with t.
+func (t task) finish(err error) {
+	if t.done != nil {
+		t.done(err)
+	}
 }
 
 // Sentinel errors returned by the pool.
 var (
 	ErrPoolClosed      = fmt.Errorf("worker pool is closed")
 	ErrShutdownTimeout = fmt.Errorf("shutdown timeout elapsed; workers were force-cancelled")
+	ErrQueueFull       = fmt.Errorf("worker pool queue is full")
 )
@@ -1,10 +1,21 @@
 package workerpool_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -194,6 +205,101 @@ func TestShutdownTimeout(t *testing.T) {
 	}
 }
 
+// TestShutdownContextAlreadyCancelled passes ShutdownContext a context that
+// is already cancelled and checks that it force-cancels running jobs at once,
+// regardless of a long ShutdownTimeout, and returns ErrShutdownTimeout.
+func TestShutdownContextAlreadyCancelled(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       4,
+		ShutdownTimeout: time.Minute, // must not be waited for
+		Logger:          quietLogger(),
+	})
+
+	var started, cancelled int64
+	for i := 0; i < 2; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt64(&started, 1)
+			<-ctx.Done()
+			if errors.Is(context.Cause(ctx), workerpool.ErrShutdownTimeout) {
+				atomic.AddInt64(&cancelled, 1)
+			}
+			return ctx.Err()
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	waitFor(t, func() bool { return atomic.LoadInt64(&started) == 2 })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := pool.ShutdownContext(ctx)
+	if !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Errorf("ShutdownContext() error = %v; want ErrShutdownTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ShutdownContext took %s; want an immediate forced cancellation", elapsed)
+	}
+	if got := atomic.LoadInt64(&cancelled); got != 2 {
+		t.Errorf("jobs cancelled with cause ErrShutdownTimeout = %d; want 2", got)
+	}
+}
+
+// TestShutdownNotBlockedBySubmitter blocks one Submit on a full queue and
+// another on a SubmitRate token while every worker runs a job that only
+// returns once cancelled, and checks that Shutdown still honours
+// ShutdownTimeout and both submitters get ErrPoolClosed.
+func TestShutdownNotBlockedBySubmitter(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       1,
+		ShutdownTimeout: 50 * time.Millisecond,
+		Logger:          quietLogger(),
+		SubmitRate:      workerpool.NewRateLimiter(time.Hour, 3),
+	})
+
+	var started int64
+	block := func(ctx context.Context) error {
+		atomic.AddInt64(&started, 1)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	// One running, one queued, one blocked on the full queue; the fourth
+	// finds the token bucket empty for an hour.
+	for i := 0; i < 2; i++ {
+		if err := pool.Submit(context.Background(), block); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	waitFor(t, func() bool { return atomic.LoadInt64(&started) == 1 })
+	blocked := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { blocked <- pool.Submit(context.Background(), block) }()
+	}
+	time.Sleep(20 * time.Millisecond) // let both submitters block
+
+	shut := make(chan error, 1)
+	go func() { shut <- pool.Shutdown() }()
+	select {
+	case err := <-shut:
+		if !errors.Is(err, workerpool.ErrShutdownTimeout) {
+			t.Errorf("Shutdown() error = %v; want ErrShutdownTimeout", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shutdown still blocked behind a waiting submitter")
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-blocked; !errors.Is(err, workerpool.ErrPoolClosed) {
+			t.Errorf("blocked Submit = %v; want ErrPoolClosed", err)
+		}
+	}
+}
+
 // ── Submit after shutdown ────────────────────────────────────────────────────
 
 // TestSubmitAfterShutdown confirms that jobs submitted after Shutdown returns
@@ -360,3 +466,2768 @@ func TestSubmitRespectsCallerContext(t *testing.T) {
 		t.Fatalf("shutdown: %v", shutErr)
 	}
 }
+
+// ── Deduplication by key ─────────────────────────────────────────────────────
+
+// TestSubmitUniqueDedupes submits the same key twice while the first job is
+// still running and checks that only one execution happens.
+func TestSubmitUniqueDedupes(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var runs int64
+	release := make(chan struct{})
+	job := func(ctx context.Context) error {
+		atomic.AddInt64(&runs, 1)
+		<-release
+		return nil
+	}
+
+	ok, err := pool.SubmitUnique(context.Background(), "user-42", job)
+	if err != nil || !ok {
+		t.Fatalf("first SubmitUnique = (%v, %v); want (true, nil)", ok, err)
+	}
+
+	ok, err = pool.SubmitUnique(context.Background(), "user-42", job)
+	if err != nil {
+		t.Fatalf("second SubmitUnique: %v", err)
+	}
+	if ok {
+		t.Error("second SubmitUnique with an active key reported enqueued; want deduped")
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := atomic.LoadInt64(&runs); got != 1 {
+		t.Errorf("job ran %d times; want 1", got)
+	}
+}
+
+// TestSubmitUniqueReleasesKey verifies that a key can be reused once its job
+// has completed, and that distinct keys are never deduplicated.
+func TestSubmitUniqueReleasesKey(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	done := make(chan struct{}, 4)
+	job := func(ctx context.Context) error {
+		done <- struct{}{}
+		return nil
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if ok, err := pool.SubmitUnique(context.Background(), key, job); !ok || err != nil {
+			t.Fatalf("SubmitUnique(%q) = (%v, %v); want (true, nil)", key, ok, err)
+		}
+	}
+	<-done
+	<-done
+
+	// The key is released after the job returns; poll briefly for the
+	// deferred release to run.
+	deadline := time.Now().Add(time.Second)
+	for {
+		ok, err := pool.SubmitUnique(context.Background(), "a", job)
+		if err != nil {
+			t.Fatalf("resubmit: %v", err)
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("key \"a\" was never released after its job completed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+// ── Job context ──────────────────────────────────────────────────────────────
+
+type traceKey struct{}
+
+// TestJobContextCancelledOnShutdown verifies that the context handed to a job
+// is cancellable: a job blocked on ctx.Done must observe a forced shutdown.
+func TestJobContextCancelledOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: 20 * time.Millisecond,
+		Logger:          quietLogger(),
+	})
+
+	started := make(chan struct{})
+	observed := make(chan error, 1)
+	_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		observed <- ctx.Err()
+		return ctx.Err()
+	})
+	<-started
+
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Errorf("Shutdown() = %v; want ErrShutdownTimeout", err)
+	}
+	if err := <-observed; !errors.Is(err, context.Canceled) {
+		t.Errorf("job observed %v; want context.Canceled", err)
+	}
+}
+
+// TestSubmitContextValuesReachJob verifies that a value stored in the Submit
+// context is readable inside the job, while shutdown cancellation still
+// reaches the same job context.
+func TestSubmitContextValuesReachJob(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: 20 * time.Millisecond,
+		Logger:          quietLogger(),
+	})
+
+	submitCtx := context.WithValue(context.Background(), traceKey{}, "trace-123")
+
+	started := make(chan struct{})
+	var gotTrace interface{}
+	var gotErr error
+	_ = pool.Submit(submitCtx, func(ctx context.Context) error {
+		gotTrace = ctx.Value(traceKey{})
+		close(started)
+		<-ctx.Done()
+		gotErr = ctx.Err()
+		return gotErr
+	})
+	<-started
+
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Errorf("Shutdown() = %v; want ErrShutdownTimeout", err)
+	}
+	if gotTrace != "trace-123" {
+		t.Errorf("ctx.Value(traceKey) = %v; want %q", gotTrace, "trace-123")
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("job observed %v; want context.Canceled from shutdown", gotErr)
+	}
+}
+
+// TestSubmitContextCancelDoesNotCancelJob verifies that cancelling the Submit
+// context after the job was accepted does not cancel the job itself.
+func TestSubmitContextCancelDoesNotCancelJob(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	submitCtx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	result := make(chan error, 1)
+	_ = pool.Submit(submitCtx, func(ctx context.Context) error {
+		<-release
+		result <- ctx.Err()
+		return nil
+	})
+
+	cancel()
+	close(release)
+
+	if err := <-result; err != nil {
+		t.Errorf("job ctx.Err() = %v after Submit ctx was cancelled; want nil", err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+// ── Prefill ──────────────────────────────────────────────────────────────────
+
+// TestPrefillStopsWhenQueueFull occupies the only worker, then prefills more
+// jobs than the queue holds. Exactly QueueSize jobs must be accepted, the rest
+// reported back, and every accepted job must run once the worker is released.
+func TestPrefillStopsWhenQueueFull(t *testing.T) {
+	t.Parallel()
+
+	const queueSize = 4
+	const extra = 3
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       queueSize,
+		ShutdownTimeout: 5 * time.Second,
+		Logger:          quietLogger(),
+	})
+
+	// Park the worker so nothing is dequeued while we prefill.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	<-started
+
+	var ran int64
+	jobs := make([]workerpool.Job, queueSize+extra)
+	for i := range jobs {
+		jobs[i] = func(ctx context.Context) error {
+			atomic.AddInt64(&ran, 1)
+			return nil
+		}
+	}
+
+	accepted, err := pool.Prefill(jobs)
+	if accepted != queueSize {
+		t.Errorf("accepted = %d; want %d (queue capacity)", accepted, queueSize)
+	}
+	if !errors.Is(err, workerpool.ErrQueueFull) {
+		t.Errorf("err = %v; want ErrQueueFull", err)
+	}
+	if rejected := len(jobs) - accepted; rejected != extra {
+		t.Errorf("not accepted = %d; want %d", rejected, extra)
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := atomic.LoadInt64(&ran); got != queueSize {
+		t.Errorf("prefilled jobs run = %d; want %d", got, queueSize)
+	}
+	if got := pool.Metrics().Submitted; got != queueSize+1 {
+		t.Errorf("Submitted = %d; want %d", got, queueSize+1)
+	}
+}
+
+// TestPrefillAfterShutdown confirms that Prefill on a closed pool accepts
+// nothing and returns ErrPoolClosed.
+func TestPrefillAfterShutdown(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	noop := func(ctx context.Context) error { return nil }
+	accepted, err := pool.Prefill([]workerpool.Job{noop, noop})
+	if accepted != 0 || !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("Prefill = (%d, %v); want (0, ErrPoolClosed)", accepted, err)
+	}
+}
+
+// ── Lazy start ───────────────────────────────────────────────────────────────
+
+// TestLazyStartDefersWorkers verifies that a LazyStart pool adds no goroutines
+// until the first Submit. It is deliberately not parallel: NumGoroutine is
+// process-wide, and non-parallel tests finish before parallel ones resume.
+func TestLazyStartDefersWorkers(t *testing.T) {
+	const workers = 4
+
+	before := runtime.NumGoroutine()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         workers,
+		ShutdownTimeout: time.Second,
+		LazyStart:       true,
+		Logger:          quietLogger(),
+	})
+
+	if got := runtime.NumGoroutine(); got > before {
+		t.Errorf("NumGoroutine after New = %d; want <= %d (no workers yet)", got, before)
+	}
+
+	done := make(chan struct{})
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(done)
+		return nil
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	<-done
+
+	if got := runtime.NumGoroutine(); got < before+workers {
+		t.Errorf("NumGoroutine after first Submit = %d; want >= %d", got, before+workers)
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+// TestLazyStartShutdownBeforeSubmit verifies that shutting down a lazy pool
+// that never received a job returns promptly and rejects later submits.
+func TestLazyStartShutdownBeforeSubmit(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		ShutdownTimeout: time.Second,
+		LazyStart:       true,
+		Logger:          quietLogger(),
+	})
+
+	errc := make(chan error, 1)
+	go func() { errc <- pool.Shutdown() }()
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("shutdown: %v", err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Shutdown on an unused lazy pool hung")
+	}
+
+	err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil })
+	if !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("Submit after Shutdown = %v; want ErrPoolClosed", err)
+	}
+}
+
+// TestLazyStartPrefillDrainedOnShutdown verifies that jobs prefilled into a
+// lazy pool still run when the pool is shut down without any Submit.
+func TestLazyStartPrefillDrainedOnShutdown(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       3,
+		ShutdownTimeout: time.Second,
+		LazyStart:       true,
+		Logger:          quietLogger(),
+	})
+
+	var ran int64
+	job := func(ctx context.Context) error {
+		atomic.AddInt64(&ran, 1)
+		return nil
+	}
+	if accepted, err := pool.Prefill([]workerpool.Job{job, job, job}); accepted != 3 || err != nil {
+		t.Fatalf("Prefill = (%d, %v); want (3, nil)", accepted, err)
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := atomic.LoadInt64(&ran); got != 3 {
+		t.Errorf("prefilled jobs run = %d; want 3", got)
+	}
+}
+
+// ── Results stream ───────────────────────────────────────────────────────────
+
+// TestResultsStreamsEveryJobOnce submits jobs with SubmitWithResult while a
+// reader drains Results, and checks that every ID appears exactly once, with
+// its value or error, before the channel is closed by Shutdown.
+func TestResultsStreamsEveryJobOnce(t *testing.T) {
+	t.Parallel()
+
+	const jobs = 50
+	errOdd := errors.New("odd")
+
+	// ResultBuffer covers every job so the assertion does not depend on how
+	// quickly the reader goroutine gets scheduled (see the drop policy).
+	pool := workerpool.New(workerpool.Config{
+		Workers:         4,
+		QueueSize:       8,
+		ResultBuffer:    jobs,
+		ShutdownTimeout: 5 * time.Second,
+		Logger:          quietLogger(),
+	})
+
+	seen := make(map[uint64]workerpool.JobResult)
+	dupes := 0
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		for r := range pool.Results() { // ends when Shutdown closes the channel
+			if _, ok := seen[r.ID]; ok {
+				dupes++
+			}
+			seen[r.ID] = r
+		}
+	}()
+
+	want := make(map[uint64]int, jobs)
+	for i := 0; i < jobs; i++ {
+		i := i
+		id, err := pool.SubmitWithResult(context.Background(), func(ctx context.Context) (any, error) {
+			if i%2 == 1 {
+				return nil, errOdd
+			}
+			return i, nil
+		})
+		if err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+		want[id] = i
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	<-readerDone
+
+	if dupes != 0 {
+		t.Errorf("%d results delivered more than once", dupes)
+	}
+	if len(seen) != jobs {
+		t.Fatalf("got %d results; want %d", len(seen), jobs)
+	}
+	for id, i := range want {
+		r, ok := seen[id]
+		switch {
+		case !ok:
+			t.Errorf("job %d (id %d) missing from Results", i, id)
+		case i%2 == 1 && !errors.Is(r.Err, errOdd):
+			t.Errorf("job %d: Err = %v; want errOdd", i, r.Err)
+		case i%2 == 0 && (r.Err != nil || r.Value != i):
+			t.Errorf("job %d: got (%v, %v); want (%d, nil)", i, r.Value, r.Err, i)
+		}
+	}
+	if got := pool.Metrics().ResultsDropped; got != 0 {
+		t.Errorf("ResultsDropped = %d; want 0", got)
+	}
+}
+
+// TestResultsDropWhenUnread verifies the drop policy: with nobody reading,
+// results beyond ResultBuffer are discarded and counted instead of blocking
+// workers.
+func TestResultsDropWhenUnread(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ResultBuffer:    1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.SubmitWithResult(context.Background(), func(ctx context.Context) (any, error) {
+			return "ok", nil
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+
+	// Shutdown must not hang even though nobody has read Results.
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	var got int
+	for range pool.Results() {
+		got++
+	}
+	if got != 1 {
+		t.Errorf("buffered results = %d; want 1", got)
+	}
+	if dropped := pool.Metrics().ResultsDropped; dropped != 2 {
+		t.Errorf("ResultsDropped = %d; want 2", dropped)
+	}
+}
+
+// ── Recent logs ring ─────────────────────────────────────────────────────────
+
+// TestRecentLogsKeepsLastN generates more log lines than LogBufferSize and
+// checks that RecentLogs returns exactly the newest N, in order, by comparing
+// against the full log the Logger received.
+func TestRecentLogsKeepsLastN(t *testing.T) {
+	t.Parallel()
+
+	const size = 5
+
+	var full bytes.Buffer
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1, // one worker → deterministic line order
+		QueueSize:       20,
+		ShutdownTimeout: time.Second,
+		LogBufferSize:   size,
+		Logger:          log.New(&full, "", 0),
+	})
+
+	for i := 0; i < 10; i++ {
+		i := i
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			return fmt.Errorf("boom %d", i) // each failure logs one line
+		})
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	all := strings.Split(strings.TrimSuffix(full.String(), "\n"), "\n")
+	if len(all) <= size {
+		t.Fatalf("only %d log lines generated; need more than %d", len(all), size)
+	}
+	want := all[len(all)-size:]
+
+	got := pool.RecentLogs()
+	if len(got) != size {
+		t.Fatalf("RecentLogs() has %d lines; want %d:\n%q", len(got), size, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("RecentLogs()[%d] = %q; want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// TestRecentLogsDisabled checks that RecentLogs is nil without LogBufferSize.
+func TestRecentLogsDisabled(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+	defer pool.Shutdown()
+
+	if got := pool.RecentLogs(); got != nil {
+		t.Errorf("RecentLogs() = %q; want nil", got)
+	}
+}
+
+// ── Admin handler ────────────────────────────────────────────────────────────
+
+// TestAdminHandler submits a few succeeding and failing jobs, then hits each
+// admin path and checks the content type and that the body reflects the
+// pool's actual counters and log lines.
+func TestAdminHandler(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       8,
+		ShutdownTimeout: time.Second,
+		LogBufferSize:   50,
+		Logger:          log.New(io.Discard, "", 0),
+	})
+	defer pool.Shutdown()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		fail := i < 2
+		wg.Add(1)
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			defer wg.Done()
+			if fail {
+				return errors.New("admin-test failure")
+			}
+			return nil
+		})
+	}
+	wg.Wait()
+	// wg.Done runs inside the job, before the worker bumps the counters and
+	// logs the failure; wait for both to land.
+	waitFor(t, func() bool {
+		m := pool.Metrics()
+		logs := strings.Join(pool.RecentLogs(), "\n")
+		return m.Succeeded+m.Failed == 5 && strings.Count(logs, "job failed") == 2
+	})
+
+	srv := httptest.NewServer(pool.AdminHandler())
+	defer srv.Close()
+
+	get := func(path string) (contentType, body string) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: status %d", path, resp.StatusCode)
+		}
+		return resp.Header.Get("Content-Type"), string(b)
+	}
+
+	t.Run("metrics", func(t *testing.T) {
+		ct, body := get("/metrics")
+		if !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("Content-Type = %q; want text/plain", ct)
+		}
+		for _, line := range []string{
+			"workerpool_jobs_submitted_total 5",
+			"workerpool_jobs_succeeded_total 3",
+			"workerpool_jobs_failed_total 2",
+			"workerpool_workers 2",
+			"# TYPE workerpool_jobs_failed_total counter",
+		} {
+			if !strings.Contains(body, line+"\n") {
+				t.Errorf("body missing %q:\n%s", line, body)
+			}
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		ct, body := get("/stats")
+		if ct != "application/json" {
+			t.Errorf("Content-Type = %q; want application/json", ct)
+		}
+		var s workerpool.Stats
+		if err := json.Unmarshal([]byte(body), &s); err != nil {
+			t.Fatalf("decode %q: %v", body, err)
+		}
+		want := workerpool.Stats{Workers: 2, QueueSize: 8, Submitted: 5, Started: 5, Succeeded: 3, Failed: 2}
+		if s != want {
+			t.Errorf("got %+v; want %+v", s, want)
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		ct, body := get("/logs")
+		if !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("Content-Type = %q; want text/plain", ct)
+		}
+		if n := strings.Count(body, "job failed: admin-test failure"); n != 2 {
+			t.Errorf("body has %d failure lines; want 2:\n%s", n, body)
+		}
+	})
+}
+
+// waitFor polls cond for up to a second.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// ── Shutdown hook ────────────────────────────────────────────────────────────
+
+// TestOnShutdownRunsOnceWithFinalMetrics checks that OnShutdown runs exactly
+// once across repeated Shutdown calls, before the first one returns, and sees
+// metrics that already include every job.
+func TestOnShutdownRunsOnceWithFinalMetrics(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+	var final workerpool.Metrics
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       10,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		OnShutdown: func(m workerpool.Metrics) {
+			atomic.AddInt64(&calls, 1)
+			final = m
+		},
+	})
+
+	for i := 0; i < 6; i++ {
+		fail := i%3 == 0
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			if fail {
+				return errors.New("fail")
+			}
+			return nil
+		})
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := atomic.LoadInt64(&calls); got != 1 {
+		t.Fatalf("OnShutdown ran %d times before Shutdown returned; want 1", got)
+	}
+	for i := 0; i < 3; i++ {
+		_ = pool.Shutdown()
+	}
+	if got := atomic.LoadInt64(&calls); got != 1 {
+		t.Errorf("OnShutdown ran %d times after repeated Shutdown; want 1", got)
+	}
+
+	want := workerpool.Metrics{Submitted: 6, Started: 6, Succeeded: 4, Failed: 2}
+	if final != want {
+		t.Errorf("hook saw %+v; want %+v", final, want)
+	}
+	if now := pool.Metrics(); final != now {
+		t.Errorf("hook saw %+v; final state is %+v", final, now)
+	}
+}
+
+// TestOnShutdownAfterForcedShutdown checks that the hook also runs when
+// Shutdown has to force-cancel, and only after the cancelled job finished.
+func TestOnShutdownAfterForcedShutdown(t *testing.T) {
+	t.Parallel()
+
+	var jobDone int32
+	var sawJobDone int32
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: 20 * time.Millisecond,
+		Logger:          quietLogger(),
+		OnShutdown: func(m workerpool.Metrics) {
+			atomic.StoreInt32(&sawJobDone, atomic.LoadInt32(&jobDone))
+		},
+	})
+
+	started := make(chan struct{})
+	_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		atomic.StoreInt32(&jobDone, 1)
+		return ctx.Err()
+	})
+	<-started
+
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Fatalf("Shutdown() = %v; want ErrShutdownTimeout", err)
+	}
+	if atomic.LoadInt32(&sawJobDone) != 1 {
+		t.Error("OnShutdown ran before the force-cancelled job returned")
+	}
+}
+
+// ── Failure injection ────────────────────────────────────────────────────────
+
+// TestFailureInjectorEvenIDs injects a failure for every even job ID and
+// checks that exactly those jobs are counted as failed without running,
+// while every odd job runs.
+func TestFailureInjectorEvenIDs(t *testing.T) {
+	t.Parallel()
+
+	const jobs = 10
+	errChaos := errors.New("chaos")
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         3,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		FailureInjector: func(id uint64) error {
+			if id%2 == 0 {
+				return errChaos
+			}
+			return nil
+		},
+	})
+
+	// IDs are assigned in submission order starting at 1, so job i has ID i+1.
+	var ran [jobs]int32
+	for i := 0; i < jobs; i++ {
+		i := i
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			atomic.StoreInt32(&ran[i], 1)
+			return nil
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	for i := range ran {
+		id := i + 1
+		if got, want := ran[i] == 1, id%2 == 1; got != want {
+			t.Errorf("job ID %d ran = %v; want %v", id, got, want)
+		}
+	}
+	m := pool.Metrics()
+	if m.Failed != jobs/2 || m.Succeeded != jobs/2 {
+		t.Errorf("Failed=%d Succeeded=%d; want %d each", m.Failed, m.Succeeded, jobs/2)
+	}
+}
+
+// TestFailureInjectorKeepsBookkeeping checks that an injected failure still
+// releases a SubmitUnique key and publishes a SubmitWithResult result, even
+// though the job wrapper never runs.
+func TestFailureInjectorKeepsBookkeeping(t *testing.T) {
+	t.Parallel()
+
+	errChaos := errors.New("chaos")
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		FailureInjector: func(uint64) error { return errChaos },
+	})
+
+	noop := func(ctx context.Context) error { return nil }
+	if ok, err := pool.SubmitUnique(context.Background(), "k", noop); !ok || err != nil {
+		t.Fatalf("SubmitUnique = (%v, %v); want (true, nil)", ok, err)
+	}
+	id, err := pool.SubmitWithResult(context.Background(), func(ctx context.Context) (any, error) {
+		return "never", nil
+	})
+	if err != nil {
+		t.Fatalf("SubmitWithResult: %v", err)
+	}
+
+	r := <-pool.Results()
+	if r.ID != id || !errors.Is(r.Err, errChaos) {
+		t.Errorf("result = %+v; want ID %d with the injected error", r, id)
+	}
+
+	// The SubmitUnique job ran (and failed) before the result job; its key
+	// must be free again.
+	if ok, err := pool.SubmitUnique(context.Background(), "k", noop); !ok || err != nil {
+		t.Errorf("SubmitUnique after injected failure = (%v, %v); want key released", ok, err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+// ── Failure log sampling ─────────────────────────────────────────────────────
+
+// TestLogSampleEveryLimitsFailureLines fails many jobs with LogSampleEvery set
+// and checks that only about total/N failure lines were written, that every
+// failure is still counted, and that Shutdown logs a suppression summary.
+func TestLogSampleEveryLimitsFailureLines(t *testing.T) {
+	t.Parallel()
+
+	const (
+		jobs  = 100
+		every = 10
+	)
+
+	var out bytes.Buffer
+	pool := workerpool.New(workerpool.Config{
+		Workers:         4,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          log.New(&out, "", 0),
+		LogSampleEvery:  every,
+	})
+
+	for i := 0; i < jobs; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			return errors.New("boom")
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	logged := strings.Count(out.String(), "job failed: boom")
+	if logged != jobs/every {
+		t.Errorf("%d failure lines logged; want %d (one per %d)", logged, jobs/every, every)
+	}
+	if m := pool.Metrics(); m.Failed != jobs {
+		t.Errorf("Failed = %d; want %d (sampling must not affect metrics)", m.Failed, jobs)
+	}
+	want := fmt.Sprintf("suppressed %d of %d job-failure log lines", jobs-jobs/every, jobs)
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("log has no summary %q:\n%s", want, out.String())
+	}
+}
+
+// TestLogSampleEveryDisabled checks that without LogSampleEvery every failure
+// is logged and no summary is written.
+func TestLogSampleEveryDisabled(t *testing.T) {
+	t.Parallel()
+
+	const jobs = 20
+
+	var out bytes.Buffer
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          log.New(&out, "", 0),
+	})
+
+	for i := 0; i < jobs; i++ {
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			return errors.New("boom")
+		})
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if logged := strings.Count(out.String(), "job failed: boom"); logged != jobs {
+		t.Errorf("%d failure lines logged; want %d", logged, jobs)
+	}
+	if strings.Contains(out.String(), "suppressed") {
+		t.Errorf("unexpected suppression summary:\n%s", out.String())
+	}
+}
+
+// ── Resize and config watcher ────────────────────────────────────────────────
+
+// TestWatchConfigResizes pushes new worker counts through WatchConfig and
+// checks that the live worker count follows each one, and that closing the
+// channel makes WatchConfig return.
+func TestWatchConfigResizes(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+	defer pool.Shutdown()
+
+	updates := make(chan int)
+	done := make(chan struct{})
+	go func() {
+		pool.WatchConfig(updates)
+		close(done)
+	}()
+
+	for _, n := range []int{5, 1, 3} {
+		updates <- n
+		waitFor(t, func() bool { return pool.Workers() == n })
+		if got := pool.Stats().Workers; got != n {
+			t.Errorf("Stats().Workers = %d; want %d", got, n)
+		}
+	}
+
+	close(updates)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WatchConfig did not return after the channel was closed")
+	}
+}
+
+// TestResizeShrinkLetsBusyWorkersFinish shrinks the pool while every worker
+// is running a job and checks that those jobs still complete, the surplus
+// workers then exit, and the remaining worker drains the queue.
+func TestResizeShrinkLetsBusyWorkersFinish(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         3,
+		QueueSize:       3,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	release := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(3)
+	for i := 0; i < 3; i++ {
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			started.Done()
+			<-release
+			return nil
+		})
+	}
+	started.Wait() // all three workers are busy
+	for i := 0; i < 3; i++ {
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error { return nil })
+	}
+
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize(1): %v", err)
+	}
+	if got := pool.Workers(); got != 3 {
+		t.Errorf("Workers() while busy = %d; want 3 (shrink waits for the jobs)", got)
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if m := pool.Metrics(); m.Succeeded != 6 {
+		t.Errorf("Succeeded = %d; want 6", m.Succeeded)
+	}
+}
+
+// TestResizePeakConcurrency resizes a pool up and then down while it is
+// saturated with blocking jobs and checks that the number of jobs running at
+// once peaks at exactly the new size each time, and that no job is lost.
+func TestResizePeakConcurrency(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       8,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var running, peak int32
+	blockingJob := func(gate <-chan struct{}) workerpool.Job {
+		return func(ctx context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			<-gate
+			return nil
+		}
+	}
+	saturate := func(size, jobs int) {
+		t.Helper()
+		atomic.StoreInt32(&peak, 0)
+		gate := make(chan struct{})
+		for i := 0; i < jobs; i++ {
+			if err := pool.Submit(context.Background(), blockingJob(gate)); err != nil {
+				t.Fatalf("submit: %v", err)
+			}
+		}
+		waitFor(t, func() bool { return atomic.LoadInt32(&running) == int32(size) })
+		time.Sleep(20 * time.Millisecond) // give any surplus worker time to show up
+		if got := atomic.LoadInt32(&peak); got != int32(size) {
+			t.Errorf("peak concurrency at size %d = %d; want %d", size, got, size)
+		}
+		close(gate)
+		waitFor(t, func() bool { return atomic.LoadInt32(&running) == 0 && pool.Stats().QueueLen == 0 })
+	}
+
+	if err := pool.Resize(5); err != nil {
+		t.Fatalf("Resize(5): %v", err)
+	}
+	saturate(5, 8)
+
+	if err := pool.Resize(2); err != nil {
+		t.Fatalf("Resize(2): %v", err)
+	}
+	waitFor(t, func() bool { return pool.Workers() == 2 })
+	saturate(2, 6)
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if m := pool.Metrics(); m.Succeeded != 14 {
+		t.Errorf("Succeeded = %d; want 14 (no job dropped by resizing)", m.Succeeded)
+	}
+}
+
+// TestResizeErrors checks that Resize rejects a non-positive size and fails
+// with ErrPoolClosed after Shutdown.
+func TestResizeErrors(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	if err := pool.Resize(0); err == nil {
+		t.Error("Resize(0) = nil; want an error")
+	}
+	_ = pool.Shutdown()
+	if err := pool.Resize(2); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("Resize after Shutdown = %v; want ErrPoolClosed", err)
+	}
+	if got := pool.Workers(); got != 0 {
+		t.Errorf("Workers() after Shutdown = %d; want 0", got)
+	}
+}
+
+// ── Submit rate limit ────────────────────────────────────────────────────────
+
+// TestSubmitRateShapesIngress submits a burst through a limiter of one token
+// per 20ms (burst 1) and checks that the burst takes about (n-1)·20ms to get
+// in, although the queue has room for all of it.
+func TestSubmitRateShapesIngress(t *testing.T) {
+	t.Parallel()
+
+	const (
+		jobs     = 6
+		interval = 20 * time.Millisecond
+	)
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		SubmitRate:      workerpool.NewRateLimiter(interval, 1),
+	})
+	defer pool.Shutdown()
+
+	start := time.Now()
+	for i := 0; i < jobs; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// The first token is free; each later one takes an interval.
+	want := (jobs - 1) * interval
+	if elapsed < want-interval/2 || elapsed > 2*want {
+		t.Errorf("%d submits took %v; want about %v", jobs, elapsed, want)
+	}
+}
+
+// TestSubmitRateRespectsContext checks that a Submit waiting for a token
+// returns the caller's context error and counts the job as dropped.
+func TestSubmitRateRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		SubmitRate:      workerpool.NewRateLimiter(time.Hour, 1),
+	})
+	defer pool.Shutdown()
+
+	noop := func(ctx context.Context) error { return nil }
+	if err := pool.Submit(context.Background(), noop); err != nil {
+		t.Fatalf("first submit (uses the burst token): %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Submit(ctx, noop); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second submit = %v; want context.DeadlineExceeded", err)
+	}
+	if m := pool.Metrics(); m.Dropped != 1 {
+		t.Errorf("Dropped = %d; want 1", m.Dropped)
+	}
+}
+
+// ── Job timeout vs shutdown cancel ───────────────────────────────────────────
+
+// TestJobTimeoutCountsTimedOut runs a job that outlives its JobTimeout and
+// checks that the job sees ErrJobTimeout as the cause, that it is counted as
+// TimedOut (not Cancelled), and that OnJobDone reports the same outcome.
+func TestJobTimeoutCountsTimedOut(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cause   error
+		outcome atomic.Value
+	)
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		JobTimeout:      20 * time.Millisecond,
+		OnJobDone: func(info workerpool.JobInfo) {
+			outcome.Store(info.Outcome)
+		},
+	})
+
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		cause = context.Cause(ctx)
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if !errors.Is(cause, workerpool.ErrJobTimeout) {
+		t.Errorf("context.Cause = %v; want ErrJobTimeout", cause)
+	}
+	m := pool.Metrics()
+	if m.TimedOut != 1 || m.Cancelled != 0 || m.Failed != 1 {
+		t.Errorf("TimedOut, Cancelled, Failed = %d, %d, %d; want 1, 0, 1", m.TimedOut, m.Cancelled, m.Failed)
+	}
+	if got := outcome.Load(); got != workerpool.OutcomeTimedOut {
+		t.Errorf("OnJobDone outcome = %v; want %v", got, workerpool.OutcomeTimedOut)
+	}
+}
+
+// TestJobTimeoutBoundsEachJob runs a job that sleeps past JobTimeout and
+// checks it sees ctx.Done with DeadlineExceeded and counts as Failed, while a
+// quick job under the same timeout succeeds. With JobTimeout 0 the job
+// context has no deadline at all.
+func TestJobTimeoutBoundsEachJob(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		JobTimeout:      20 * time.Millisecond,
+	})
+
+	var sleepErr error
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		select {
+		case <-time.After(time.Second): // far past JobTimeout
+			return nil
+		case <-ctx.Done():
+			sleepErr = ctx.Err()
+			return sleepErr
+		}
+	}); err != nil {
+		t.Fatalf("submit slow: %v", err)
+	}
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("submit quick: %v", err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if !errors.Is(sleepErr, context.DeadlineExceeded) {
+		t.Errorf("slow job saw %v; want context.DeadlineExceeded", sleepErr)
+	}
+	if m := pool.Metrics(); m.Failed != 1 || m.Succeeded != 1 {
+		t.Errorf("Failed, Succeeded = %d, %d; want 1, 1", m.Failed, m.Succeeded)
+	}
+
+	unbounded := workerpool.New(workerpool.Config{Workers: 1, Logger: quietLogger()})
+	var hasDeadline bool
+	if err := unbounded.SubmitWait(context.Background(), func(ctx context.Context) error {
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	}); err != nil {
+		t.Fatalf("SubmitWait: %v", err)
+	}
+	unbounded.Shutdown()
+	if hasDeadline {
+		t.Error("JobTimeout 0: job context has a deadline; want none")
+	}
+}
+
+// TestShutdownCancelCountsCancelled runs a job that outlives ShutdownTimeout
+// (with a much longer JobTimeout) and checks that the job sees
+// ErrShutdownTimeout as the cause and is counted as Cancelled, not TimedOut.
+func TestShutdownCancelCountsCancelled(t *testing.T) {
+	t.Parallel()
+
+	var cause error
+	started := make(chan struct{})
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       1,
+		ShutdownTimeout: 20 * time.Millisecond,
+		Logger:          quietLogger(),
+		JobTimeout:      time.Hour,
+	})
+
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		cause = context.Cause(ctx)
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	<-started
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Fatalf("shutdown = %v; want ErrShutdownTimeout", err)
+	}
+
+	if !errors.Is(cause, workerpool.ErrShutdownTimeout) {
+		t.Errorf("context.Cause = %v; want ErrShutdownTimeout", cause)
+	}
+	m := pool.Metrics()
+	if m.Cancelled != 1 || m.TimedOut != 0 || m.Failed != 1 {
+		t.Errorf("Cancelled, TimedOut, Failed = %d, %d, %d; want 1, 0, 1", m.Cancelled, m.TimedOut, m.Failed)
+	}
+}
+
+// ── Replace with a new pool ──────────────────────────────────────────────────
+
+// TestReplaceWithRunsEveryJobOnce submits jobs from several goroutines
+// through the old handle while the pool is replaced mid-stream, and checks
+// that every job ran exactly once and that both pools ran some of them.
+func TestReplaceWithRunsEveryJobOnce(t *testing.T) {
+	t.Parallel()
+
+	const (
+		producers = 4
+		perProd   = 50
+		total     = producers * perProd
+	)
+
+	old := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       10,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var runs [total]int32
+	var submitted int32
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := p * perProd; i < (p+1)*perProd; i++ {
+				i := i
+				if err := old.Submit(context.Background(), func(ctx context.Context) error {
+					atomic.AddInt32(&runs[i], 1)
+					time.Sleep(100 * time.Microsecond)
+					return nil
+				}); err != nil {
+					t.Errorf("submit %d: %v", i, err)
+				}
+				atomic.AddInt32(&submitted, 1)
+			}
+		}(p)
+	}
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&submitted) >= total/4 })
+	next, err := old.ReplaceWith(workerpool.Config{
+		Workers:         4,
+		QueueSize:       50,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+	if err != nil {
+		t.Fatalf("ReplaceWith: %v", err)
+	}
+
+	wg.Wait()
+	if err := old.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	for i := range runs {
+		if n := runs[i]; n != 1 {
+			t.Errorf("job %d ran %d times; want 1", i, n)
+		}
+	}
+	oldDone, newDone := old.Metrics().Succeeded, next.Metrics().Succeeded
+	if oldDone == 0 || newDone == 0 || oldDone+newDone != total {
+		t.Errorf("succeeded old, new = %d, %d; want both > 0 and summing to %d", oldDone, newDone, total)
+	}
+}
+
+// TestReplaceWithForwardsAndChains checks that the old handle forwards to
+// the newest pool after two replacements, and that Shutdown on it closes the
+// whole chain.
+func TestReplaceWithForwardsAndChains(t *testing.T) {
+	t.Parallel()
+
+	cfg := workerpool.Config{Workers: 1, QueueSize: 4, ShutdownTimeout: time.Second, Logger: quietLogger()}
+	old := workerpool.New(cfg)
+	mid, err := old.ReplaceWith(cfg)
+	if err != nil {
+		t.Fatalf("first ReplaceWith: %v", err)
+	}
+	waitFor(t, func() bool { return old.Stats().Closed }) // old is draining
+	newest, err := old.ReplaceWith(cfg)
+	if err != nil {
+		t.Fatalf("second ReplaceWith: %v", err)
+	}
+
+	noop := func(ctx context.Context) error { return nil }
+	if err := old.Submit(context.Background(), noop); err != nil {
+		t.Fatalf("submit through old handle: %v", err)
+	}
+	if got := newest.Metrics().Submitted; got != 1 {
+		t.Errorf("newest Submitted = %d; want 1", got)
+	}
+	if got := mid.Metrics().Submitted; got != 0 {
+		t.Errorf("mid Submitted = %d; want 0", got)
+	}
+
+	if err := old.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if err := newest.Submit(context.Background(), noop); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("submit to newest after Shutdown = %v; want ErrPoolClosed", err)
+	}
+	if _, err := old.ReplaceWith(cfg); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("ReplaceWith after Shutdown = %v; want ErrPoolClosed", err)
+	}
+}
+
+// TestReplaceWithBlockedSubmitWithResult blocks a SubmitWithResult on a
+// full pool, replaces the pool, and checks the job is submitted again to the
+// new pool: its result arrives on the new pool's Results under the returned
+// ID, and only the new pool counts it.
+func TestReplaceWithBlockedSubmitWithResult(t *testing.T) {
+	t.Parallel()
+
+	cfg := workerpool.Config{Workers: 1, QueueSize: 1, ShutdownTimeout: time.Second, Logger: quietLogger()}
+	old := workerpool.New(cfg)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	noop := func(ctx context.Context) error { return nil }
+	_ = old.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	_ = old.Submit(context.Background(), noop) // the queue is full
+
+	type submitted struct {
+		id  uint64
+		err error
+	}
+	blocked := make(chan submitted, 1)
+	go func() {
+		id, err := old.SubmitWithResult(context.Background(), func(ctx context.Context) (any, error) {
+			return "forwarded", nil
+		})
+		blocked <- submitted{id, err}
+	}()
+	waitFor(t, func() bool { return old.Metrics().Submitted == 3 }) // waiting for room
+
+	next, err := old.ReplaceWith(cfg)
+	if err != nil {
+		t.Fatalf("ReplaceWith: %v", err)
+	}
+	var got submitted
+	select {
+	case got = <-blocked:
+	case <-time.After(time.Second):
+		t.Fatal("SubmitWithResult still blocked 1s after ReplaceWith")
+	}
+	if got.err != nil {
+		t.Fatalf("SubmitWithResult: %v", got.err)
+	}
+	select {
+	case r := <-next.Results():
+		if r.ID != got.id || r.Value != "forwarded" || r.Err != nil {
+			t.Errorf("result = %+v; want ID %d, Value forwarded", r, got.id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no result on the new pool's Results")
+	}
+
+	close(release)
+	if err := old.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if m := old.Metrics(); m.Submitted != 2 || m.Dropped != 0 {
+		t.Errorf("old Submitted, Dropped = %d, %d; want 2, 0", m.Submitted, m.Dropped)
+	}
+	if m := next.Metrics(); m.Submitted != 1 || m.Succeeded != 1 {
+		t.Errorf("new Submitted, Succeeded = %d, %d; want 1, 1", m.Submitted, m.Succeeded)
+	}
+}
+
+// ── Tracer ───────────────────────────────────────────────────────────────────
+
+type spanKey struct{}
+
+// fakeSpan records what a fakeTracer saw for one span.
+type fakeSpan struct {
+	name     string
+	job      int // set by the job itself, read from its context
+	finished int
+	err      error
+}
+
+// fakeTracer stores every span it starts and puts it in the context.
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (f *fakeTracer) StartSpan(ctx context.Context, name string) (context.Context, func(error)) {
+	s := &fakeSpan{name: name, job: -1}
+	f.mu.Lock()
+	f.spans = append(f.spans, s)
+	f.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, s), func(err error) {
+		f.mu.Lock()
+		s.finished++
+		s.err = err
+		f.mu.Unlock()
+	}
+}
+
+// TestTracerSpanPerJob runs jobs, every other one failing, through a fake
+// tracer and checks that each job ran inside its own span, which was
+// finished exactly once with that job's error.
+func TestTracerSpanPerJob(t *testing.T) {
+	t.Parallel()
+
+	const jobs = 6
+	tracer := &fakeTracer{}
+	pool := workerpool.New(workerpool.Config{
+		Workers:         3,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		Tracer:          tracer,
+	})
+
+	jobErr := make([]error, jobs)
+	for i := 0; i < jobs; i++ {
+		i := i
+		if i%2 == 1 {
+			jobErr[i] = fmt.Errorf("job %d failed", i)
+		}
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			s, ok := ctx.Value(spanKey{}).(*fakeSpan)
+			if !ok {
+				return errors.New("no span in job context")
+			}
+			tracer.mu.Lock()
+			s.job = i
+			tracer.mu.Unlock()
+			return jobErr[i]
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if len(tracer.spans) != jobs {
+		t.Fatalf("started %d spans; want %d", len(tracer.spans), jobs)
+	}
+	seen := make(map[int]bool)
+	for _, s := range tracer.spans {
+		if s.job < 0 || seen[s.job] {
+			t.Errorf("span for job %d: want exactly one span per job", s.job)
+			continue
+		}
+		seen[s.job] = true
+		if s.name != workerpool.JobSpanName {
+			t.Errorf("span name = %q; want %q", s.name, workerpool.JobSpanName)
+		}
+		if s.finished != 1 || s.err != jobErr[s.job] {
+			t.Errorf("job %d span finished %d times with %v; want once with %v", s.job, s.finished, s.err, jobErr[s.job])
+		}
+	}
+}
+
+// ── Job lifecycle hooks ──────────────────────────────────────────────────────
+
+// TestJobLifecycleHooks records a latency per job with OnJobStart and
+// OnJobComplete and checks there is exactly one start and one latency entry
+// per submitted job, each covering the job's sleep, with its error.
+func TestJobLifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	const (
+		jobs  = 8
+		sleep = 2 * time.Millisecond
+	)
+	errOdd := errors.New("odd job")
+	var (
+		mu        sync.Mutex
+		starts    int
+		latencies []time.Duration
+		failed    int
+	)
+	pool := workerpool.New(workerpool.Config{
+		Workers:         3,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		OnJobStart: func(ctx context.Context) {
+			mu.Lock()
+			starts++
+			mu.Unlock()
+		},
+		OnJobComplete: func(ctx context.Context, err error, dur time.Duration) {
+			mu.Lock()
+			latencies = append(latencies, dur)
+			if errors.Is(err, errOdd) {
+				failed++
+			}
+			mu.Unlock()
+		},
+	})
+
+	for i := 0; i < jobs; i++ {
+		i := i
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			time.Sleep(sleep)
+			if i%2 == 1 {
+				return errOdd
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if starts != jobs || len(latencies) != jobs {
+		t.Fatalf("OnJobStart calls, latencies = %d, %d; want %d, %d", starts, len(latencies), jobs, jobs)
+	}
+	for i, d := range latencies {
+		if d < sleep {
+			t.Errorf("latency[%d] = %v; want >= %v", i, d, sleep)
+		}
+	}
+	if failed != jobs/2 {
+		t.Errorf("OnJobComplete saw %d errOdd; want %d", failed, jobs/2)
+	}
+}
+
+// ── LockOSThread ─────────────────────────────────────────────────────────────
+
+// TestLockOSThreadPool runs jobs on a pool whose workers lock their OS
+// threads and checks that every job completes, Shutdown is clean, and the
+// worker goroutines exit (unlocking their threads as they go). Not parallel:
+// it compares runtime.NumGoroutine before and after.
+func TestLockOSThreadPool(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	const jobs = 20
+	pool := workerpool.New(workerpool.Config{
+		Workers:         4,
+		QueueSize:       jobs,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		LockOSThread:    true,
+	})
+
+	var done int32
+	for i := 0; i < jobs; i++ {
+		if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt32(&done, 1)
+			return nil
+		}); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&done); got != jobs {
+		t.Errorf("completed %d jobs; want %d", got, jobs)
+	}
+	if got := pool.Workers(); got != 0 {
+		t.Errorf("Workers() = %d after Shutdown; want 0", got)
+	}
+	waitFor(t, func() bool { return runtime.NumGoroutine() <= before })
+}
+
+// ── Overflow sink ────────────────────────────────────────────────────────────
+
+// TestOverflowSinkReceivesUncompletedJobs forces a shutdown with two jobs in
+// flight and three still queued, then submits after close through Submit,
+// SubmitUnique and Prefill. It replays every job the sink received and
+// checks that exactly those eight ran, each once.
+func TestOverflowSinkReceivesUncompletedJobs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		sunk     []workerpool.Job
+		replayed = map[int]int{}
+		replay   atomic.Bool
+	)
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       3,
+		ShutdownTimeout: 20 * time.Millisecond,
+		Logger:          quietLogger(),
+		OverflowSink: func(job workerpool.Job) {
+			mu.Lock()
+			sunk = append(sunk, job)
+			mu.Unlock()
+		},
+	})
+
+	// job i blocks until cancelled, unless it is being replayed from the sink.
+	job := func(i int) workerpool.Job {
+		return func(ctx context.Context) error {
+			if replay.Load() {
+				replayed[i]++
+				return nil
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	}
+
+	for i := 0; i < 5; i++ { // 0, 1 in flight; 2, 3, 4 queued
+		if err := pool.Submit(context.Background(), job(i)); err != nil {
+			t.Fatalf("submit %d: %v", i, err)
+		}
+		if i == 1 {
+			waitFor(t, func() bool { return pool.Metrics().Started == 2 })
+		}
+	}
+	if err := pool.Shutdown(); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Fatalf("shutdown = %v; want ErrShutdownTimeout", err)
+	}
+
+	if err := pool.Submit(context.Background(), job(5)); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Fatalf("submit after shutdown = %v; want ErrPoolClosed", err)
+	}
+	if _, err := pool.SubmitUnique(context.Background(), "k", job(6)); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Fatalf("SubmitUnique after shutdown = %v; want ErrPoolClosed", err)
+	}
+	if _, err := pool.Prefill([]workerpool.Job{job(7)}); !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Fatalf("Prefill after shutdown = %v; want ErrPoolClosed", err)
+	}
+
+	replay.Store(true)
+	for _, j := range sunk {
+		if err := j(context.Background()); err != nil {
+			t.Errorf("replayed job: %v", err)
+		}
+	}
+
+	if len(sunk) != 8 {
+		t.Errorf("sink received %d jobs; want 8", len(sunk))
+	}
+	for i := 0; i < 8; i++ {
+		if replayed[i] != 1 {
+			t.Errorf("job %d replayed %d times; want 1", i, replayed[i])
+		}
+	}
+	if m := pool.Metrics(); m.Cancelled != 5 {
+		t.Errorf("Cancelled = %d; want 5", m.Cancelled)
+	}
+}
+
+// ── Submit and wait ──────────────────────────────────────────────────────────
+
+// TestSubmitWaitReturnsJobError checks that SubmitWait returns a failing job's
+// sentinel verbatim and nil for a successful one, that Succeeded and Failed
+// still tally, and that after Shutdown it returns ErrPoolClosed like Submit.
+func TestSubmitWaitReturnsJobError(t *testing.T) {
+	t.Parallel()
+
+	errSentinel := errors.New("sentinel")
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var ran int32
+	if err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}); err != nil {
+		t.Errorf("SubmitWait(ok job) = %v; want <nil>", err)
+	}
+	if atomic.LoadInt32(&ran) != 1 {
+		t.Error("SubmitWait returned before the job ran")
+	}
+
+	err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		return errSentinel
+	})
+	if err != errSentinel {
+		t.Errorf("SubmitWait(failing job) = %v; want the sentinel itself", err)
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if m := pool.Metrics(); m.Succeeded != 1 || m.Failed != 1 {
+		t.Errorf("Succeeded, Failed = %d, %d; want 1, 1", m.Succeeded, m.Failed)
+	}
+
+	err = pool.SubmitWait(context.Background(), func(ctx context.Context) error { return nil })
+	if !errors.Is(err, workerpool.ErrPoolClosed) {
+		t.Errorf("SubmitWait after shutdown = %v; want ErrPoolClosed", err)
+	}
+}
+
+// TestSubmitWaitRespectsContext checks that SubmitWait stops waiting when its
+// ctx is cancelled while the job runs, and that the job still completes.
+func TestSubmitWaitRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	release := make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := pool.SubmitWait(ctx, func(context.Context) error {
+		<-release
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SubmitWait = %v; want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := pool.Metrics().Succeeded; got != 1 {
+		t.Errorf("Succeeded = %d; want 1 (the job finished after the caller left)", got)
+	}
+}
+
+// ── Scheduled submissions ────────────────────────────────────────────────────
+
+// TestEverySubmitsPerIntervalUntilCancel lets Every run for about ten
+// intervals, checks roughly ten jobs ran, then checks that cancel stops
+// further submissions.
+func TestEverySubmitsPerIntervalUntilCancel(t *testing.T) {
+	t.Parallel()
+
+	const (
+		interval = 20 * time.Millisecond
+		n        = 10
+	)
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+	defer pool.Shutdown()
+
+	var ran int32
+	cancel := pool.Every(interval, func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	})
+	time.Sleep(n*interval + interval/2)
+	cancel()
+	cancel() // idempotent
+
+	got := atomic.LoadInt32(&ran)
+	if got < n/2 || got > n+1 {
+		t.Errorf("Every ran %d jobs in %d intervals; want about %d", got, n, n)
+	}
+
+	// Let a submission that raced cancel finish, then check nothing follows.
+	time.Sleep(interval)
+	settled := atomic.LoadInt32(&ran)
+	time.Sleep(3 * interval)
+	if after := atomic.LoadInt32(&ran); after != settled {
+		t.Errorf("ran %d jobs after cancel; want 0", after-settled)
+	}
+}
+
+// TestEveryRejectsNonPositiveInterval checks that Every panics in the
+// caller's goroutine for a zero or negative interval, so the caller can
+// recover, and that the pool keeps working.
+func TestEveryRejectsNonPositiveInterval(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{Workers: 1, ShutdownTimeout: time.Second, Logger: quietLogger()})
+	defer pool.Shutdown()
+
+	noop := func(ctx context.Context) error { return nil }
+	for _, interval := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Every(%s) did not panic", interval)
+				}
+			}()
+			pool.Every(interval, noop)
+		}()
+	}
+	if err := pool.SubmitWait(context.Background(), noop); err != nil {
+		t.Errorf("SubmitWait after the panics: %v", err)
+	}
+}
+
+// TestShutdownHaltsScheduler schedules with Every and a far-off At, shuts the
+// pool down, and checks that no scheduled job is submitted afterwards and no
+// Submit was rejected, i.e. the scheduler stopped before the pool closed.
+func TestShutdownHaltsScheduler(t *testing.T) {
+	t.Parallel()
+
+	const interval = 10 * time.Millisecond
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var ran int32
+	job := func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}
+	pool.Every(interval, job)
+	pool.At(time.Now().Add(time.Hour), job)
+	pool.At(time.Now(), job)
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&ran) >= 3 })
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	stopped := atomic.LoadInt32(&ran)
+
+	time.Sleep(5 * interval)
+	if got := atomic.LoadInt32(&ran); got != stopped {
+		t.Errorf("ran %d jobs after Shutdown; want 0", got-stopped)
+	}
+	if m := pool.Metrics(); m.Dropped != 0 {
+		t.Errorf("Dropped = %d; want 0 (no scheduled Submit after close)", m.Dropped)
+	}
+
+	pool.Every(interval, job)() // after Shutdown: schedules nothing
+	pool.At(time.Now(), job)
+	time.Sleep(3 * interval)
+	if got := atomic.LoadInt32(&ran); got != stopped {
+		t.Errorf("schedule after Shutdown ran %d jobs; want 0", got-stopped)
+	}
+}
+
+// ── Submit site ──────────────────────────────────────────────────────────────
+
+// TestCaptureSubmitSite fails a job submitted with CaptureSubmitSite and
+// checks that JobInfo.SubmitSite and the failure log line both point at the
+// test's Submit call, also through SubmitWait's extra frames.
+func TestCaptureSubmitSite(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer
+	var mu sync.Mutex
+	var sites []string
+	pool := workerpool.New(workerpool.Config{
+		Workers:           1,
+		QueueSize:         1,
+		ShutdownTimeout:   time.Second,
+		Logger:            log.New(&logs, "", 0),
+		CaptureSubmitSite: true,
+		OnJobDone: func(info workerpool.JobInfo) {
+			mu.Lock()
+			sites = append(sites, info.SubmitSite)
+			mu.Unlock()
+		},
+	})
+	fail := func(ctx context.Context) error { return errors.New("boom") }
+
+	_, file, line, _ := runtime.Caller(0)
+	if err := pool.SubmitWait(context.Background(), fail); err == nil { // line+1
+		t.Fatal("SubmitWait: want the job's error")
+	}
+	if err := pool.Submit(context.Background(), fail); err != nil { // line+4
+		t.Fatalf("submit: %v", err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	want := []string{fmt.Sprintf("%s:%d", file, line+1), fmt.Sprintf("%s:%d", file, line+4)}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sites) != 2 || sites[0] != want[0] || sites[1] != want[1] {
+		t.Errorf("SubmitSite = %q; want %q", sites, want)
+	}
+	for _, site := range want {
+		if !strings.Contains(logs.String(), "(submitted at "+site+")") {
+			t.Errorf("log has no failure line submitted at %s:\n%s", site, logs.String())
+		}
+	}
+}
+
+// TestCaptureSubmitSiteOffByDefault checks that without CaptureSubmitSite
+// JobInfo.SubmitSite is empty, log lines carry no site, and Submit allocates
+// less than with it on, i.e. the stack is not walked at all. Not parallel:
+// testing.AllocsPerRun refuses to run in parallel tests.
+func TestCaptureSubmitSiteOffByDefault(t *testing.T) {
+	newPool := func(capture bool, logs io.Writer, sites chan<- string) *workerpool.Pool {
+		return workerpool.New(workerpool.Config{
+			Workers:           1,
+			QueueSize:         1,
+			ShutdownTimeout:   time.Second,
+			Logger:            log.New(logs, "", 0),
+			CaptureSubmitSite: capture,
+			OnJobDone: func(info workerpool.JobInfo) {
+				select {
+				case sites <- info.SubmitSite:
+				default:
+				}
+			},
+		})
+	}
+
+	var logs bytes.Buffer
+	sites := make(chan string, 1)
+	off := newPool(false, &logs, sites)
+	off.SubmitWait(context.Background(), func(ctx context.Context) error { return errors.New("boom") })
+	if site := <-sites; site != "" {
+		t.Errorf("SubmitSite = %q; want empty", site)
+	}
+
+	nop := func(ctx context.Context) error { return nil }
+	submit := func(p *workerpool.Pool) func() {
+		return func() { p.Submit(context.Background(), nop) }
+	}
+	on := newPool(true, io.Discard, make(chan string))
+	allocsOff := testing.AllocsPerRun(100, submit(off))
+	allocsOn := testing.AllocsPerRun(100, submit(on))
+	off.Shutdown()
+	on.Shutdown()
+
+	if strings.Contains(logs.String(), "submitted at") {
+		t.Errorf("log mentions a submit site with the feature off:\n%s", logs.String())
+	}
+	if allocsOff >= allocsOn {
+		t.Errorf("Submit allocs off = %v, on = %v; want fewer with the feature off", allocsOff, allocsOn)
+	}
+}
+
+// ── Panic recovery ───────────────────────────────────────────────────────────
+
+// TestPanickingJobDoesNotKillWorker runs a panicking job followed by a normal
+// one on a single worker and checks the panic is counted and logged with its
+// stack, the worker survives to run the next job, and SubmitWait gets the
+// panic back as a *PanicError.
+func TestPanickingJobDoesNotKillWorker(t *testing.T) {
+	t.Parallel()
+
+	var logs bytes.Buffer // read only after Shutdown
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          log.New(&logs, "", 0),
+	})
+
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		panic("nil map write")
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	var ran int32
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&ran, 1)
+		return nil
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	errSentinel := errors.New("sentinel")
+	err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		panic(errSentinel)
+	})
+	var perr *workerpool.PanicError
+	if !errors.As(err, &perr) || !errors.Is(err, errSentinel) {
+		t.Errorf("SubmitWait(panicking job) = %v; want a *PanicError wrapping the sentinel", err)
+	}
+
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if atomic.LoadInt32(&ran) != 1 {
+		t.Error("the job after the panic did not run")
+	}
+	if m := pool.Metrics(); m.Panicked != 2 || m.Failed != 2 || m.Succeeded != 1 {
+		t.Errorf("Panicked, Failed, Succeeded = %d, %d, %d; want 2, 2, 1", m.Panicked, m.Failed, m.Succeeded)
+	}
+	if out := logs.String(); !strings.Contains(out, "panicked: nil map write") || !strings.Contains(out, "goroutine ") {
+		t.Errorf("log lacks the panic value and stack:\n%s", out)
+	}
+}
+
+// ── Priority levels ──────────────────────────────────────────────────────────
+
+// TestSubmitPriorityJumpsTheLine keeps the only worker busy, queues a
+// low-priority job and then a high-priority one, and checks the
+// high-priority job runs first. It also checks out-of-range priorities are
+// rejected.
+func TestSubmitPriorityJumpsTheLine(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		PriorityLevels:  2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started // the worker is busy
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) workerpool.Job {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+	if err := pool.SubmitPriority(context.Background(), 1, record("low")); err != nil {
+		t.Fatalf("SubmitPriority(1): %v", err)
+	}
+	if err := pool.SubmitPriority(context.Background(), 0, record("high")); err != nil {
+		t.Fatalf("SubmitPriority(0): %v", err)
+	}
+	for _, prio := range []int{-1, 2} {
+		if err := pool.SubmitPriority(context.Background(), prio, record("bad")); err == nil {
+			t.Errorf("SubmitPriority(%d) = nil; want an out-of-range error", prio)
+		}
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "high,low" {
+		t.Errorf("run order = %s; want high,low", got)
+	}
+}
+
+// TestPriorityStarvationBounded queues many high-priority jobs and one
+// low-priority job behind a busy worker and checks that the low one runs
+// after at most PriorityStarvationLimit high ones, not after all of them.
+func TestPriorityStarvationBounded(t *testing.T) {
+	t.Parallel()
+
+	const limit = 3
+	pool := workerpool.New(workerpool.Config{
+		Workers:                 1,
+		QueueSize:               16,
+		PriorityLevels:          2,
+		PriorityStarvationLimit: limit,
+		ShutdownTimeout:         time.Second,
+		Logger:                  quietLogger(),
+	})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) workerpool.Job {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+	_ = pool.Submit(context.Background(), record("low")) // Submit uses the lowest level
+	for i := 0; i < 10; i++ {
+		_ = pool.SubmitPriority(context.Background(), 0, record("high"))
+	}
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	pos := slices.Index(order, "low")
+	if pos != limit {
+		t.Errorf("low job ran at position %d of %v; want %d (after %d high jobs)", pos, order, limit, limit)
+	}
+}
+
+// ── Preemption ───────────────────────────────────────────────────────────────
+
+// TestSubmitPreemptibleYieldsToUrgent keeps the only worker busy with a
+// preemptible low-priority job, submits an urgent one, and checks the low
+// job is cancelled with ErrPreempted, the urgent job runs, and the low job
+// then runs again to completion.
+func TestSubmitPreemptibleYieldsToUrgent(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		PriorityLevels:  2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var runs int32
+	var cause atomic.Value
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	err := pool.SubmitPreemptible(context.Background(), 1, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		started <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			cause.Store(context.Cause(ctx))
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		t.Fatalf("SubmitPreemptible: %v", err)
+	}
+	<-started // the worker is busy with the low job
+
+	highRan := make(chan struct{})
+	if err := pool.SubmitPriority(context.Background(), 0, func(ctx context.Context) error {
+		close(highRan)
+		return nil
+	}); err != nil {
+		t.Fatalf("SubmitPriority(0): %v", err)
+	}
+	select {
+	case <-highRan:
+	case <-time.After(time.Second):
+		t.Fatal("urgent job did not run while the low job held the only worker")
+	}
+	if got, _ := cause.Load().(error); !errors.Is(got, workerpool.ErrPreempted) {
+		t.Errorf("low job's context cause = %v; want ErrPreempted", got)
+	}
+
+	<-started // the low job runs again
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	m := pool.Metrics()
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Errorf("low job ran %d times; want 2", got)
+	}
+	if m.Preempted != 1 || m.Succeeded != 2 || m.Failed != 0 || m.Cancelled != 0 || m.Started != 2 {
+		t.Errorf("metrics = %+v; want Preempted=1 Succeeded=2 Started=2 and no failures", m)
+	}
+}
+
+// TestSubmitPriorityNotPreempted checks that a job submitted with
+// SubmitPriority keeps its worker when an urgent job arrives: the urgent job
+// waits for it instead.
+func TestSubmitPriorityNotPreempted(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		PriorityLevels:  2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	var mu sync.Mutex
+	var order []string
+	release := make(chan struct{})
+	started := make(chan struct{})
+	_ = pool.SubmitPriority(context.Background(), 1, func(ctx context.Context) error {
+		close(started)
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() != nil {
+			order = append(order, "low-cancelled")
+			return ctx.Err()
+		}
+		order = append(order, "low")
+		return nil
+	})
+	<-started
+	// Any preemption happens inside SubmitPriority, before it returns.
+	_ = pool.SubmitPriority(context.Background(), 0, func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, "high")
+		return nil
+	})
+
+	close(release)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "low,high" {
+		t.Errorf("run order = %s; want low,high", got)
+	}
+	if m := pool.Metrics(); m.Preempted != 0 {
+		t.Errorf("Preempted = %d; want 0", m.Preempted)
+	}
+}
+
+// ── Saturation gauges ────────────────────────────────────────────────────────
+
+// TestMetricsSaturationGauges holds every worker on a barrier with more jobs
+// queued behind them and checks that ActiveWorkers equals the worker count
+// and QueueDepth the backlog, and that both return to 0 once drained.
+func TestMetricsSaturationGauges(t *testing.T) {
+	t.Parallel()
+
+	const workers, backlog = 3, 5
+	pool := workerpool.New(workerpool.Config{
+		Workers:         workers,
+		QueueSize:       backlog,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+	})
+
+	barrier := make(chan struct{})
+	var started sync.WaitGroup
+	started.Add(workers)
+	for i := 0; i < workers; i++ {
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error {
+			started.Done()
+			<-barrier
+			return nil
+		})
+	}
+	started.Wait()
+	for i := 0; i < backlog; i++ {
+		_ = pool.Submit(context.Background(), func(ctx context.Context) error { return nil })
+	}
+
+	if m := pool.Metrics(); m.ActiveWorkers != workers || m.QueueDepth != backlog {
+		t.Errorf("ActiveWorkers, QueueDepth = %d, %d; want %d, %d", m.ActiveWorkers, m.QueueDepth, workers, backlog)
+	}
+
+	close(barrier)
+	waitFor(t, func() bool {
+		m := pool.Metrics()
+		return m.ActiveWorkers == 0 && m.QueueDepth == 0 && m.Succeeded == workers+backlog
+	})
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+// ── Retries ──────────────────────────────────────────────────────────────────
+
+// TestRetryUntilSuccess runs a job that fails twice and then succeeds with
+// MaxRetries 3 and checks it ends as Succeeded with Retried == 2, that
+// SubmitWait sees only the final nil, and that OnJobDone fires once.
+func TestRetryUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	var doneCalls int32
+	var backoffs []int
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		MaxRetries:      3,
+		RetryBackoff: func(attempt int) time.Duration {
+			backoffs = append(backoffs, attempt) // only the worker calls it
+			return time.Millisecond
+		},
+		OnJobDone: func(workerpool.JobInfo) { atomic.AddInt32(&doneCalls, 1) },
+	})
+
+	var runs int32
+	err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		if atomic.AddInt32(&runs, 1) <= 2 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("SubmitWait = %v; want <nil> from the successful attempt", err)
+	}
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	m := pool.Metrics()
+	if m.Succeeded != 1 || m.Failed != 0 || m.Retried != 2 || m.Started != 1 {
+		t.Errorf("Succeeded, Failed, Retried, Started = %d, %d, %d, %d; want 1, 0, 2, 1",
+			m.Succeeded, m.Failed, m.Retried, m.Started)
+	}
+	if got := atomic.LoadInt32(&runs); got != 3 {
+		t.Errorf("job ran %d times; want 3", got)
+	}
+	if fmt.Sprint(backoffs) != "[1 2]" {
+		t.Errorf("RetryBackoff called with %v; want [1 2]", backoffs)
+	}
+	if got := atomic.LoadInt32(&doneCalls); got != 1 {
+		t.Errorf("OnJobDone called %d times; want 1", got)
+	}
+}
+
+// TestRetryGivesUp checks that a job failing every time runs 1+MaxRetries
+// times and ends as Failed with its last error, and that a panicking job is
+// not retried.
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		MaxRetries:      2,
+		RetryBackoff:    func(int) time.Duration { return 0 },
+	})
+
+	var runs int32
+	err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		return fmt.Errorf("attempt %d", atomic.AddInt32(&runs, 1))
+	})
+	if err == nil || err.Error() != "attempt 3" {
+		t.Errorf("SubmitWait = %v; want the last attempt's error, attempt 3", err)
+	}
+
+	var panics int32
+	_ = pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&panics, 1)
+		panic("bug")
+	})
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&panics); got != 1 {
+		t.Errorf("panicking job ran %d times; want 1 (no retry)", got)
+	}
+	if m := pool.Metrics(); m.Failed != 2 || m.Retried != 2 {
+		t.Errorf("Failed, Retried = %d, %d; want 2, 2", m.Failed, m.Retried)
+	}
+}
+
+// TestRetryFreesWorker gives a single worker a job whose first attempt
+// fails with a long backoff, then a second job, and checks that the second
+// job runs during the backoff — the worker does not sit on the retry — and
+// that the retry still runs afterwards.
+func TestRetryFreesWorker(t *testing.T) {
+	t.Parallel()
+
+	const backoff = 200 * time.Millisecond
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		QueueSize:       2,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		MaxRetries:      1,
+		RetryBackoff:    func(int) time.Duration { return backoff },
+	})
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	note := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+	var runs int32
+	start := time.Now()
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			note("flaky failed")
+			return errors.New("transient")
+		}
+		note("flaky retried")
+		return nil
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	var otherAt time.Duration
+	if err := pool.SubmitWait(context.Background(), func(ctx context.Context) error {
+		otherAt = time.Since(start)
+		note("other")
+		return nil
+	}); err != nil {
+		t.Fatalf("SubmitWait: %v", err)
+	}
+	if otherAt >= backoff {
+		t.Errorf("second job ran after %v; want it during the %v backoff", otherAt, backoff)
+	}
+	if err := pool.Shutdown(); err != nil { // waits for the pending retry
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	if got := strings.Join(order, ", "); got != "flaky failed, other, flaky retried" {
+		t.Errorf("order = %s; want flaky failed, other, flaky retried", got)
+	}
+	if m := pool.Metrics(); m.Succeeded != 2 || m.Retried != 1 || m.Started != 2 {
+		t.Errorf("Succeeded, Retried, Started = %d, %d, %d; want 2, 1, 2", m.Succeeded, m.Retried, m.Started)
+	}
+}
+
+// TestRetryCancelledDuringBackoff checks that a forced shutdown during a
+// retry's backoff ends the job as Cancelled without waiting out the backoff.
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	t.Parallel()
+
+	pool := workerpool.New(workerpool.Config{
+		Workers:         1,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		MaxRetries:      1,
+		RetryBackoff:    func(int) time.Duration { return time.Hour },
+	})
+
+	var runs int32
+	if err := pool.Submit(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("transient")
+	}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	waitFor(t, func() bool { return atomic.LoadInt32(&runs) == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.ShutdownContext(ctx); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Errorf("ShutdownContext = %v; want ErrShutdownTimeout", err)
+	}
+	if m := pool.Metrics(); m.Cancelled != 1 || m.Retried != 0 {
+		t.Errorf("Cancelled, Retried = %d, %d; want 1, 0", m.Cancelled, m.Retried)
+	}
+}
+
+// TestExponentialBackoffBounds checks that the default backoff doubles per
+// attempt within its jitter and never exceeds the cap, and that the same
+// seed gives the same delays.
+func TestExponentialBackoffBounds(t *testing.T) {
+	t.Parallel()
+
+	a := workerpool.ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond, rand.New(rand.NewSource(7)))
+	b := workerpool.ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond, rand.New(rand.NewSource(7)))
+	for attempt := 1; attempt <= 4; attempt++ {
+		if da, db := a(attempt), b(attempt); da != db {
+			t.Errorf("attempt %d: %v vs %v; want equal delays from equal seeds", attempt, da, db)
+		}
+	}
+
+	backoff := workerpool.ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond, nil)
+	for attempt, base := range map[int]time.Duration{1: 10, 2: 20, 3: 40} {
+		base *= time.Millisecond
+		if d := backoff(attempt); d < base || d >= base+base/2 {
+			t.Errorf("backoff(%d) = %v; want in [%v, %v)", attempt, d, base, base+base/2)
+		}
+	}
+	for _, attempt := range []int{5, 40, 100} {
+		if d := backoff(attempt); d != 100*time.Millisecond {
+			t.Errorf("backoff(%d) = %v; want the cap, 100ms", attempt, d)
+		}
+	}
+}
+
+// ── Persistence ──────────────────────────────────────────────────────────────
+
+// memPersister is an in-memory Persister that survives across pools, as a
+// database would across restarts.
+type memPersister struct {
+	mu   sync.Mutex
+	jobs map[string]workerpool.PersistedJob
+}
+
+func newMemPersister() *memPersister {
+	return &memPersister{jobs: make(map[string]workerpool.PersistedJob)}
+}
+
+func (m *memPersister) Save(job workerpool.PersistedJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *memPersister) Done(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *memPersister) Load() ([]workerpool.PersistedJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]workerpool.PersistedJob, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		out = append(out, j)
+	}
+	return out, nil
+}
+
+func (m *memPersister) pending() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.jobs)
+}
+
+// persistRun is the state a test shares with its persistJobs. A reloaded
+// job is a fresh value decoded from JSON, so it finds the state by name.
+type persistRun struct {
+	mu   sync.Mutex
+	ran  []int
+	gate chan struct{} // jobs block until closed or cancelled
+}
+
+var persistRuns sync.Map // test name → *persistRun
+
+// persistJob is the Runnable the persistence tests submit.
+type persistJob struct {
+	Test string
+	N    int
+}
+
+func (j persistJob) Run(ctx context.Context) error {
+	v, _ := persistRuns.Load(j.Test)
+	run := v.(*persistRun)
+	select {
+	case <-run.gate:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	run.mu.Lock()
+	run.ran = append(run.ran, j.N)
+	run.mu.Unlock()
+	return nil
+}
+
+func init() {
+	workerpool.RegisterJobType[persistJob]("workerpool_test.persistJob")
+}
+
+func newPersistRun(t *testing.T, open bool) *persistRun {
+	run := &persistRun{gate: make(chan struct{})}
+	if open {
+		close(run.gate)
+	}
+	persistRuns.Store(t.Name(), run)
+	return run
+}
+
+func (r *persistRun) sorted() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := slices.Clone(r.ran)
+	slices.Sort(out)
+	return out
+}
+
+// TestSubmitRunnablePersistsUntilDone checks that SubmitRunnable saves each
+// job, that the saved copies are gone once the jobs have run, and that an
+// unregistered Runnable is rejected without being saved.
+func TestSubmitRunnablePersistsUntilDone(t *testing.T) {
+	t.Parallel()
+
+	run := newPersistRun(t, false)
+	store := newMemPersister()
+	pool := workerpool.New(workerpool.Config{
+		Workers:         2,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		Persister:       store,
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := pool.SubmitRunnable(context.Background(), persistJob{Test: t.Name(), N: i}); err != nil {
+			t.Fatalf("SubmitRunnable: %v", err)
+		}
+	}
+	if got := store.pending(); got != 3 {
+		t.Errorf("pending before the jobs ran = %d; want 3", got)
+	}
+
+	type unregistered struct{ persistJob }
+	err := pool.SubmitRunnable(context.Background(), unregistered{})
+	if !errors.Is(err, workerpool.ErrUnregisteredJobType) {
+		t.Errorf("SubmitRunnable(unregistered) = %v; want ErrUnregisteredJobType", err)
+	}
+
+	close(run.gate)
+	if err := pool.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := run.sorted(); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("ran %v; want [0 1 2]", got)
+	}
+	if got := store.pending(); got != 0 {
+		t.Errorf("pending after the jobs ran = %d; want 0", got)
+	}
+}
+
+// TestPersistedJobsReloadedAfterRestart force-shuts a pool down with one job
+// running and three queued, checks all four stay saved, then builds a new
+// pool on the same Persister — the restart — and checks it runs exactly
+// those four and clears them.
+func TestPersistedJobsReloadedAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	run := newPersistRun(t, false)
+	store := newMemPersister()
+	cfg := workerpool.Config{
+		Workers:         1,
+		QueueSize:       4,
+		ShutdownTimeout: time.Second,
+		Logger:          quietLogger(),
+		Persister:       store,
+	}
+
+	pool := workerpool.New(cfg)
+	for i := 0; i < 4; i++ {
+		if err := pool.SubmitRunnable(context.Background(), persistJob{Test: t.Name(), N: i}); err != nil {
+			t.Fatalf("SubmitRunnable: %v", err)
+		}
+	}
+	waitFor(t, func() bool { return pool.Metrics().ActiveWorkers == 1 })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // crash: nothing gets to finish
+	if err := pool.ShutdownContext(ctx); !errors.Is(err, workerpool.ErrShutdownTimeout) {
+		t.Fatalf("ShutdownContext = %v; want ErrShutdownTimeout", err)
+	}
+	if got := run.sorted(); len(got) != 0 {
+		t.Fatalf("ran %v before the restart; want nothing", got)
+	}
+	if got := store.pending(); got != 4 {
+		t.Fatalf("pending after the crash = %d; want 4", got)
+	}
+
+	close(run.gate)
+	restarted := workerpool.New(cfg)
+	waitFor(t, func() bool { return len(run.sorted()) == 4 })
+	if err := restarted.Shutdown(); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if got := run.sorted(); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("ran %v after the restart; want [0 1 2 3]", got)
+	}
+	if got := store.pending(); got != 0 {
+		t.Errorf("pending after the restart = %d; want 0", got)
+	}
+}
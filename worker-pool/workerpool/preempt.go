@@ -0,0 +1,82 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrPreempted is the context.Cause of a preemptible job's context when a
+// more urgent job took its worker. The job should return promptly; it is
+// re-queued and runs again from the start.
+var ErrPreempted = errors.New("job preempted by a more urgent one")
+
+// runningJob is an in-flight preemptible job, registered for its run.
+type runningJob struct {
+	level     int
+	cancel    context.CancelCauseFunc
+	preempted bool
+}
+
+// SubmitPreemptible enqueues job at priority like SubmitPriority, and marks
+// it preemptible: when a more urgent job is submitted while every worker is
+// busy, the pool may cancel this job's context with cause ErrPreempted and
+// put it back on its queue, so the urgent job gets the worker.
+//
+// A preempted job runs again from scratch — it must be safe to restart —
+// and counts in Metrics.Preempted, not as a failure or a retry. A job that
+// ignores the cancellation and returns nil is simply done. Only jobs from
+// less urgent levels than the newcomer are preempted, one per urgent
+// submit, the least urgent first.
+func (p *Pool) SubmitPreemptible(ctx context.Context, priority int, job Job) error {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitPreemptible(ctx, priority, job)
+	}
+	if priority < 0 || priority >= len(p.levels) {
+		return fmt.Errorf("workerpool: priority %d out of range [0, %d)", priority, len(p.levels))
+	}
+	err := p.submitTo(ctx, priority, task{id: p.newID(), job: job, preemptible: true})
+	if next := p.replacedBy(err); next != nil {
+		return next.SubmitPreemptible(ctx, priority, job)
+	}
+	return err
+}
+
+// track registers t's run as preemptible with cancel as its way out.
+func (p *Pool) track(t task, cancel context.CancelCauseFunc) {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	p.running[t.id] = &runningJob{level: t.level, cancel: cancel}
+}
+
+// untrack ends t's registration and reports whether it was preempted.
+func (p *Pool) untrack(t task) bool {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	r := p.running[t.id]
+	delete(p.running, t.id)
+	return r != nil && r.preempted
+}
+
+// preemptFor makes room for a job just queued at level: if every worker is
+// busy, it cancels the least urgent preemptible job running at a less
+// urgent level, if any.
+func (p *Pool) preemptFor(level int) {
+	if atomic.LoadInt32(&p.active) < atomic.LoadInt32(&p.live) {
+		return // a worker is free to take it
+	}
+
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	var victim *runningJob
+	for _, r := range p.running {
+		if !r.preempted && r.level > level && (victim == nil || r.level > victim.level) {
+			victim = r
+		}
+	}
+	if victim != nil {
+		victim.preempted = true
+		victim.cancel(ErrPreempted)
+	}
+}
@@ -0,0 +1,47 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Prefill enqueues jobs in order without ever blocking, stopping at the first
+// job that does not fit in the queue. It returns how many jobs were accepted;
+// jobs[accepted:] were not enqueued and the caller still owns them.
+//
+//   - (len(jobs), nil)     every job was enqueued.
+//   - (n, ErrQueueFull)    the queue filled up after n jobs.
+//   - (0, ErrPoolClosed)   the pool is shutting down; every job goes to
+//     Config.OverflowSink, if set.
+//
+// Prefill is meant for priming the queue before a benchmark or burst, so that
+// workers start with a full backlog instead of racing the producer. Jobs see
+// no submit-context values, as if submitted with context.Background().
+func (p *Pool) Prefill(jobs []Job) (accepted int, err error) {
+	if next := p.successor.Load(); next != nil {
+		return next.Prefill(jobs)
+	}
+
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+
+	if atomic.LoadInt32(&p.closed) == 1 {
+		atomic.AddInt64(&p.metrics.Dropped, int64(len(jobs)))
+		for _, job := range jobs {
+			p.overflow(task{job: job})
+		}
+		return 0, ErrPoolClosed
+	}
+
+	site := p.submitSite(context.Background())
+	for _, job := range jobs {
+		select {
+		case p.jobs <- task{id: p.newID(), job: job, submitCtx: context.Background(), site: site, level: len(p.levels) - 1}:
+			atomic.AddInt64(&p.metrics.Submitted, 1)
+			accepted++
+		default:
+			return accepted, ErrQueueFull
+		}
+	}
+	return accepted, nil
+}
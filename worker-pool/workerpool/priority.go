@@ -0,0 +1,145 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// defaultStarvationLimit is Config.PriorityStarvationLimit when unset.
+const defaultStarvationLimit = 8
+
+// SubmitPriority enqueues job at priority, 0 being the most urgent and
+// Config.PriorityLevels-1 the level Submit and the other Submit* methods use.
+// Workers take the most urgent queued job first, within the starvation bound
+// of Config.PriorityStarvationLimit. Otherwise it behaves like Submit.
+//
+// It returns an error for a priority outside [0, PriorityLevels).
+func (p *Pool) SubmitPriority(ctx context.Context, priority int, job Job) error {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitPriority(ctx, priority, job)
+	}
+	if priority < 0 || priority >= len(p.levels) {
+		return fmt.Errorf("workerpool: priority %d out of range [0, %d)", priority, len(p.levels))
+	}
+	err := p.submitTo(ctx, priority, task{id: p.newID(), job: job})
+	if next := p.replacedBy(err); next != nil {
+		return next.SubmitPriority(ctx, priority, job)
+	}
+	return err
+}
+
+// queueLen returns the number of jobs waiting at every priority level.
+func (p *Pool) queueLen() int {
+	n := 0
+	for _, q := range p.levels {
+		n += len(q)
+	}
+	return n
+}
+
+// closeQueues closes every priority level, so workers drain them and exit.
+func (p *Pool) closeQueues() {
+	for _, q := range p.levels {
+		close(q)
+	}
+}
+
+// next waits for the next task for worker id. It reports false, after
+// logging why, when the worker must exit: stop was closed by Resize, or every
+// queue is closed and drained by Shutdown.
+//
+// With one level it is a plain receive. With several, passed[i] counts how
+// many jobs this worker took while level i had work waiting; a level passed
+// over PriorityStarvationLimit times is served next even if more urgent work
+// is queued, so a steady stream of urgent jobs delays the others by a bounded
+// number of jobs instead of starving them.
+func (p *Pool) next(id int, stop <-chan struct{}, passed []int) (task, bool) {
+	if len(p.levels) == 1 {
+		select {
+		case <-stop:
+			p.cfg.Logger.Printf("[worker %d] stopped by resize", id)
+			return task{}, false
+		case t, ok := <-p.jobs:
+			if !ok {
+				p.cfg.Logger.Printf("[worker %d] exited", id)
+				return task{}, false
+			}
+			return t, true
+		}
+	}
+
+	for {
+		if level := p.pickLevel(passed); level >= 0 {
+			select {
+			case t := <-p.levels[level]:
+				p.served(level, passed)
+				return t, true
+			default:
+				continue // another worker took it; pick again
+			}
+		}
+
+		// Nothing queued: wait on stop and every level still open.
+		cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stop)}}
+		for _, q := range p.levels {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(q)})
+		}
+		for {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				p.cfg.Logger.Printf("[worker %d] stopped by resize", id)
+				return task{}, false
+			}
+			if ok {
+				p.served(chosen-1, passed)
+				return v.Interface().(task), true
+			}
+			cases[chosen].Chan = reflect.Value{} // closed: never ready again
+			if allDisabled(cases[1:]) {
+				p.cfg.Logger.Printf("[worker %d] exited", id)
+				return task{}, false
+			}
+		}
+	}
+}
+
+// pickLevel returns the level a worker should take its next job from: the
+// first level with work waiting that has been passed over too often, else
+// the most urgent level with work waiting, else -1.
+func (p *Pool) pickLevel(passed []int) int {
+	pick := -1
+	for i, q := range p.levels {
+		if len(q) == 0 {
+			continue
+		}
+		if passed[i] >= p.cfg.PriorityStarvationLimit {
+			return i
+		}
+		if pick < 0 {
+			pick = i
+		}
+	}
+	return pick
+}
+
+// served records that a worker took a job from level: every other level with
+// work waiting was passed over once more.
+func (p *Pool) served(level int, passed []int) {
+	for i, q := range p.levels {
+		if i != level && len(q) > 0 {
+			passed[i]++
+		}
+	}
+	passed[level] = 0
+}
+
+// allDisabled reports whether every case has been disabled.
+func allDisabled(cases []reflect.SelectCase) bool {
+	for _, c := range cases {
+		if c.Chan.IsValid() {
+			return false
+		}
+	}
+	return true
+}
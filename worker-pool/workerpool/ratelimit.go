@@ -0,0 +1,86 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket: it holds up to burst tokens and gains one
+// every interval. Wait takes a token, blocking until one is available. It is
+// safe for concurrent use and can be shared by several pools.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter returns a limiter allowing one event per interval on
+// average, with bursts of up to burst events (at least 1). It starts full.
+func NewRateLimiter(interval time.Duration, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a token is available and takes it, or returns ctx.Err()
+// if ctx is done first (no token is consumed then).
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.wait(ctx, nil)
+}
+
+// wait is Wait that also gives up, returning ErrPoolClosed, once stop is
+// closed; a nil stop never fires.
+func (l *RateLimiter) wait(ctx context.Context, stop <-chan struct{}) error {
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			// A token should be there now; loop to take it (another waiter
+			// may have beaten us to it).
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-stop:
+			timer.Stop()
+			return ErrPoolClosed
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long until the next
+// token is due.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.interval > 0 {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	} else {
+		l.tokens = l.burst // no interval: unlimited
+	}
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
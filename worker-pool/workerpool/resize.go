@@ -0,0 +1,72 @@
+package workerpool
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Resize changes the number of workers to n (at least 1). Growing starts the
+// new workers at once. Shrinking asks the surplus workers to exit; an idle
+// worker exits immediately, a busy one after finishing its current job, so
+// Workers reaches n shortly after Resize returns. Queued jobs are unaffected.
+//
+// Resize starts a LazyStart pool that has not started yet. It returns
+// ErrPoolClosed after Shutdown.
+func (p *Pool) Resize(n int) error {
+	if n < 1 {
+		return fmt.Errorf("workerpool: resize to %d: need at least 1 worker", n)
+	}
+	if next := p.successor.Load(); next != nil {
+		return next.Resize(n)
+	}
+	p.startOnce.Do(p.startWorkers)
+
+	p.resizeMu.Lock()
+	defer p.resizeMu.Unlock()
+
+	if atomic.LoadInt32(&p.closed) == 1 {
+		return ErrPoolClosed
+	}
+
+	from := len(p.stops)
+	for len(p.stops) < n {
+		p.startWorkerLocked()
+	}
+	for len(p.stops) > n {
+		last := len(p.stops) - 1
+		close(p.stops[last])
+		p.stops = p.stops[:last]
+	}
+	atomic.StoreInt32(&p.size, int32(n))
+
+	if from != n {
+		p.cfg.Logger.Printf("[pool] resized from %d to %d workers", from, n)
+	}
+	return nil
+}
+
+// Workers returns the number of worker goroutines currently running. After
+// a shrinking Resize it lags behind the requested size until busy workers
+// finish their jobs.
+func (p *Pool) Workers() int {
+	return int(atomic.LoadInt32(&p.live))
+}
+
+// WatchConfig resizes the pool to every worker count received on updates,
+// so concurrency can be tuned at runtime from an external config source.
+// Invalid counts are logged and skipped. It blocks until updates is closed,
+// or until an update arrives after Shutdown; run it in its own goroutine:
+//
+//	go pool.WatchConfig(workerCounts)
+func (p *Pool) WatchConfig(updates <-chan int) {
+	for n := range updates {
+		if err := p.Resize(n); err != nil {
+			p.cfg.Logger.Printf("[pool] config update ignored: %v", err)
+			if errors.Is(err, ErrPoolClosed) {
+				return
+			}
+		}
+	}
+	p.cfg.Logger.Printf("[pool] config watcher stopped")
+}
@@ -0,0 +1,68 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ResultJob is a Job that also produces a value.
+type ResultJob func(ctx context.Context) (any, error)
+
+// JobResult is what a ResultJob publishes on the Results channel.
+type JobResult struct {
+	ID    uint64 // as returned by SubmitWithResult
+	Value any    // ResultJob's value; meaningful only if Err is nil
+	Err   error  // ResultJob's error
+}
+
+// SubmitWithResult enqueues job like Submit and returns the ID under which
+// its outcome will be published on Results. The ID is assigned even when
+// Submit fails, but nothing is published for a job that was not accepted.
+// If ReplaceWith hands p over while the submit waits for room, the job is
+// submitted to the new pool, and the ID and result are the new pool's.
+//
+// Jobs the worker does not run — skipped by a forced shutdown or failed by
+// Config.FailureInjector — still publish a result, carrying the reason as Err.
+func (p *Pool) SubmitWithResult(ctx context.Context, job ResultJob) (uint64, error) {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitWithResult(ctx, job)
+	}
+
+	id := p.newID()
+
+	var v any // the last attempt's value; written and read on the worker
+	err := p.submit(ctx, task{
+		id: id,
+		job: func(ctx context.Context) (err error) {
+			v, err = job(ctx)
+			return err
+		},
+		done: func(err error) { p.publish(JobResult{ID: id, Value: v, Err: err}) },
+	})
+	if next := p.replacedBy(err); next != nil {
+		return next.SubmitWithResult(ctx, job)
+	}
+	return id, err
+}
+
+// Results streams the outcome of every job submitted with SubmitWithResult,
+// in completion order. The channel is closed once Shutdown has waited for all
+// workers to exit, so ranging over it ends after the last result.
+//
+// Drop policy: the channel is buffered (Config.ResultBuffer) and workers never
+// block on it. If the buffer is full because nobody is reading, the result is
+// discarded and Metrics.ResultsDropped is incremented. Callers that need every
+// result must keep a reader running.
+func (p *Pool) Results() <-chan JobResult {
+	return p.results
+}
+
+// publish hands r to Results without blocking the worker.
+func (p *Pool) publish(r JobResult) {
+	select {
+	case p.results <- r:
+	default:
+		atomic.AddInt64(&p.metrics.ResultsDropped, 1)
+		p.cfg.Logger.Printf("[pool] results buffer full — dropped result of job %d", r.ID)
+	}
+}
@@ -0,0 +1,124 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExponentialBackoff returns a Config.RetryBackoff that waits base before
+// the first retry and doubles per attempt, plus up to 50 % random jitter so
+// jobs that failed together do not all retry at the same instant, capped at
+// maxDelay.
+//
+// The jitter is drawn from r, so a test can pass rand.New(rand.NewSource(1))
+// for a repeatable sequence; nil means a source seeded from the clock. The
+// returned func guards r with a mutex, since workers call it concurrently.
+func ExponentialBackoff(base, maxDelay time.Duration, r *rand.Rand) func(attempt int) time.Duration {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	var mu sync.Mutex
+	return func(attempt int) time.Duration {
+		delay := base << (attempt - 1)
+		if delay <= 0 || delay > maxDelay {
+			delay = maxDelay // also guards against shift overflow
+		}
+		wait := delay
+		if half := int64(delay / 2); half > 0 {
+			mu.Lock()
+			wait += time.Duration(r.Int63n(half))
+			mu.Unlock()
+		}
+		return min(wait, maxDelay)
+	}
+}
+
+// shouldRetry reports whether a job that ended with outcome on its attempt
+// (1-based) gets another one. Only ordinary failures and timeouts are
+// retried: a panic is a bug that would recur, and a cancelled job is being
+// shut down.
+func (p *Pool) shouldRetry(outcome JobOutcome, perr *PanicError, attempt int) bool {
+	if attempt > p.cfg.MaxRetries || perr != nil {
+		return false
+	}
+	return outcome == OutcomeFailed || outcome == OutcomeTimedOut
+}
+
+// attemptKey is the context key under which runAttempt stores the attempt
+// being run, for WillRetry.
+type attemptKey struct{}
+
+// attemptInfo is the value under attemptKey: the pool, and how many failed
+// runs came before this one.
+type attemptInfo struct {
+	p       *Pool
+	attempt int
+}
+
+// WillRetry reports whether the pool will run the job again if the current
+// attempt returns err — whether err is only an intermediate failure under
+// Config.MaxRetries, not the job's outcome. ctx must be the context the pool
+// passed to the job. A job that reports its outcome somewhere other than its
+// return value uses it to report exactly once, on the final attempt.
+//
+// A forced shutdown that cancels ctx after WillRetry returned true still ends
+// the job without a retry.
+func WillRetry(ctx context.Context, err error) bool {
+	a, ok := ctx.Value(attemptKey{}).(attemptInfo)
+	if !ok || err == nil {
+		return false
+	}
+	outcome := classify(ctx, err)
+	var perr *PanicError
+	if errors.As(err, &perr) {
+		outcome = OutcomeFailed
+	}
+	return a.p.shouldRetry(outcome, perr, a.attempt+1)
+}
+
+// requeueAfter puts t back on its queue after d, freeing worker id for
+// other jobs meanwhile: a retry after its backoff, or a preempted job right
+// away. err is the last run's error, reported if a forced shutdown cancels
+// t while it waits.
+//
+// The waiting task counts in p.wg like a running job, so a graceful
+// Shutdown waits for it. If Shutdown has closed the queues by the time d
+// ends, t runs right here instead of being dropped.
+func (p *Pool) requeueAfter(id int, t task, d time.Duration, err error) {
+	p.wg.Add(1) // the calling worker is counted too, so p.wg is not zero here
+	go func() {
+		defer p.wg.Done()
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-p.workerCtx.Done():
+			p.conclude(id, t, OutcomeCancelled, err, nil) // forced shutdown while waiting
+			return
+		}
+		if !p.requeue(t) {
+			p.runTask(id, t)
+		}
+	}()
+}
+
+// requeue sends t back to the queue of its priority level, under the same
+// protocol as enqueue, and reports whether it did: false once Shutdown has
+// begun.
+func (p *Pool) requeue(t task) bool {
+	p.submitMu.RLock()
+	defer p.submitMu.RUnlock()
+	if atomic.LoadInt32(&p.closed) == 1 {
+		return false
+	}
+	select {
+	case p.levels[t.level] <- t:
+		return true
+	case <-p.closing:
+		return false
+	}
+}
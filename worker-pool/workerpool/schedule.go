@@ -0,0 +1,110 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// scheduler tracks the goroutines started by Every and At. ctx is cancelled
+// by Shutdown; mu orders starting a schedule against that, so wg.Add never
+// races wg.Wait.
+type scheduler struct {
+	mu   sync.Mutex
+	ctx  context.Context
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// Every submits job once per interval (> 0), starting one interval from now,
+// until cancel is called or the pool shuts down. A tick whose Submit is still
+// waiting for queue space delays the next one rather than piling up, so a
+// slow pool sees at most one pending scheduled submission per schedule.
+//
+// Every after Shutdown schedules nothing; cancel is safe to call any number
+// of times, also after Shutdown. Like time.NewTicker, Every panics if
+// interval <= 0 — here, in the caller's goroutine, not the scheduler's.
+func (p *Pool) Every(interval time.Duration, job Job) (cancel func()) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("workerpool: Every with non-positive interval %s", interval))
+	}
+	return p.schedule(p.submitSite(context.Background()), func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !p.scheduledSubmit(ctx, job) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// At submits job once at t (immediately if t has passed), unless the pool
+// shuts down first.
+func (p *Pool) At(t time.Time, job Job) {
+	p.schedule(p.submitSite(context.Background()), func(ctx context.Context) {
+		timer := time.NewTimer(time.Until(t))
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			p.scheduledSubmit(ctx, job)
+		case <-ctx.Done():
+		}
+	})
+}
+
+// schedule runs loop on a scheduler goroutine with a context cancelled by
+// cancel or by Shutdown, whichever comes first, and carrying site (the call
+// to Every or At) as the submit site of its jobs.
+func (p *Pool) schedule(site string, loop func(ctx context.Context)) context.CancelFunc {
+	p.sched.mu.Lock()
+	defer p.sched.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(p.sched.ctx)
+	if ctx.Err() != nil {
+		cancel() // already shut down
+		return cancel
+	}
+	p.sched.wg.Add(1)
+	go func() {
+		defer p.sched.wg.Done()
+		defer cancel()
+		if site != "" {
+			loop(context.WithValue(ctx, submitSiteKey{}, site))
+			return
+		}
+		loop(ctx)
+	}()
+	return cancel
+}
+
+// scheduledSubmit submits job for a schedule and reports whether the
+// schedule should keep going: not once the pool is closed or ctx is done.
+func (p *Pool) scheduledSubmit(ctx context.Context, job Job) bool {
+	err := p.Submit(ctx, job)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, ErrPoolClosed), ctx.Err() != nil:
+		return false
+	default:
+		p.cfg.Logger.Printf("[pool] scheduled submit failed: %v", err)
+		return true
+	}
+}
+
+// stopSchedules cancels every Every and At and waits for their goroutines
+// to return, so no scheduled Submit is in progress afterwards.
+func (p *Pool) stopSchedules() {
+	p.sched.mu.Lock()
+	p.sched.stop()
+	p.sched.mu.Unlock()
+	p.sched.wg.Wait()
+}
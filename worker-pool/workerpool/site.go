@@ -0,0 +1,51 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// submitSiteKey carries the submit site of scheduled jobs (Every, At), whose
+// Submit runs on a scheduler goroutine with no user frame on its stack.
+type submitSiteKey struct{}
+
+// pkgPrefix is the prefix of every function name in this package, e.g.
+// "github.com/…/workerpool." for "github.com/…/workerpool.(*Pool).Submit".
+var pkgPrefix = reflect.TypeOf(Pool{}).PkgPath() + "."
+
+// submitSite returns the "file:line" that submitted a job, or "" when
+// Config.CaptureSubmitSite is off — then it costs one branch. The site is
+// the first caller outside this package, so Submit, SubmitUnique,
+// SubmitWithResult, SubmitWait and forwarding after ReplaceWith all report
+// the user's call.
+func (p *Pool) submitSite(ctx context.Context) string {
+	if !p.cfg.CaptureSubmitSite {
+		return ""
+	}
+	if site, ok := ctx.Value(submitSiteKey{}).(string); ok {
+		return site
+	}
+
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, pkgPrefix) {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// siteSuffix is appended to t's failure log lines.
+func (t task) siteSuffix() string {
+	if t.site == "" {
+		return ""
+	}
+	return " (submitted at " + t.site + ")"
+}
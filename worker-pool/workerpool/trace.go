@@ -0,0 +1,32 @@
+package workerpool
+
+import "context"
+
+// Tracer starts a span around each job. StartSpan returns the context the
+// job runs with (carrying the span) and a function that ends the span with
+// the job's error, nil on success. An OpenTelemetry adapter is a few lines
+// in the caller's code, so this package does not depend on it:
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, name string) (context.Context, func(error)) {
+//		ctx, span := t.tracer.Start(ctx, name)
+//		return ctx, func(err error) {
+//			if err != nil {
+//				span.RecordError(err)
+//				span.SetStatus(codes.Error, err.Error())
+//			}
+//			span.End()
+//		}
+//	}
+type Tracer interface {
+	StartSpan(ctx context.Context, name string) (context.Context, func(err error))
+}
+
+// JobSpanName is the name of the span started for every job.
+const JobSpanName = "workerpool.job"
+
+// noopTracer is the default Tracer: no spans, no allocations.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
@@ -0,0 +1,50 @@
+package workerpool
+
+import "context"
+
+// SubmitUnique enqueues job only if no other job with the same key is
+// currently queued or running. It reports whether the job was enqueued:
+//
+//   - (true, nil)   the job was accepted; key stays active until it returns.
+//   - (false, nil)  a job with this key is already active; job was dropped.
+//   - (false, err)  Submit failed (ErrPoolClosed or caller cancellation);
+//     the key is released immediately.
+//
+// Deduplication covers only in-flight work: once the job returns, the same
+// key can be submitted again. This suits idempotent jobs such as "refresh
+// user 42", where a second request arriving mid-flight adds nothing.
+func (p *Pool) SubmitUnique(ctx context.Context, key string, job Job) (bool, error) {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitUnique(ctx, key, job)
+	}
+
+	p.keysMu.Lock()
+	if _, busy := p.activeKeys[key]; busy {
+		p.keysMu.Unlock()
+		return false, nil
+	}
+	p.activeKeys[key] = struct{}{}
+	p.keysMu.Unlock()
+
+	t := task{
+		id:   p.newID(),
+		job:  job,
+		done: func(error) { p.releaseKey(key) },
+	}
+
+	if err := p.submit(ctx, t); err != nil {
+		p.releaseKey(key)
+		if next := p.replacedBy(err); next != nil {
+			return next.SubmitUnique(ctx, key, job)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseKey marks key as no longer active.
+func (p *Pool) releaseKey(key string) {
+	p.keysMu.Lock()
+	delete(p.activeKeys, key)
+	p.keysMu.Unlock()
+}
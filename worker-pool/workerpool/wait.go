@@ -0,0 +1,39 @@
+package workerpool
+
+import "context"
+
+// SubmitWait enqueues job like Submit, then blocks until a worker has run it
+// and returns the error job returned — verbatim, so errors.Is and == work on
+// the caller's sentinels. Submit errors (ErrPoolClosed, caller cancellation
+// while waiting for queue space) are returned as Submit returns them.
+//
+// If ctx is done while the job is queued or running, SubmitWait returns
+// ctx.Err() without waiting further; the job still runs and is counted in
+// Metrics as usual. A job the worker does not run (skipped by a forced
+// shutdown or failed by Config.FailureInjector) returns the reason.
+func (p *Pool) SubmitWait(ctx context.Context, job Job) error {
+	if next := p.successor.Load(); next != nil {
+		return next.SubmitWait(ctx, job)
+	}
+
+	// Buffered so the worker never blocks on a submitter that stopped waiting.
+	done := make(chan error, 1)
+	err := p.submit(ctx, task{
+		id:   p.newID(),
+		job:  job,
+		done: func(err error) { done <- err },
+	})
+	if next := p.replacedBy(err); next != nil {
+		return next.SubmitWait(ctx, job)
+	}
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}